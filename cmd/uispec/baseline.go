@@ -0,0 +1,197 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gnana997/uispec/pkg/validator"
+)
+
+// baselineVersion is the format version of baseline files.
+const baselineVersion = 1
+
+// baseline records the violations a project has accepted, so that later runs
+// report only new ones.
+type baseline struct {
+	Version int             `json:"version"`
+	Entries []baselineEntry `json:"entries"`
+}
+
+// baselineEntry is an accepted violation, or Count identical ones. The
+// fingerprint covers the rule, component, file and snippet but not the line
+// number, so entries survive code being added above them.
+type baselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	Component   string `json:"component,omitempty"`
+	File        string `json:"file"`
+	Snippet     string `json:"snippet"`
+	Count       int    `json:"count"`
+}
+
+// baselineResult is how a run compared with the baseline.
+type baselineResult struct {
+	Matched int             `json:"matched"`         // violations hidden by the baseline
+	Fixed   []baselineEntry `json:"fixed,omitempty"` // entries that no longer occur
+}
+
+// newBaselineEntry returns the entry for a violation in the given file,
+// whose path is relative to the project root so that the baseline does not
+// depend on the directory uispec runs in.
+func newBaselineEntry(file string, lines []string, v validator.Violation) baselineEntry {
+	e := baselineEntry{
+		Rule:      v.Rule,
+		Component: v.Component,
+		File:      file,
+		Snippet:   normalizeSnippet(lineAt(lines, v.Line)),
+		Count:     1,
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{e.Rule, e.Component, e.File, e.Snippet}, "\x00")))
+	e.Fingerprint = hex.EncodeToString(sum[:8])
+	return e
+}
+
+// normalizeSnippet collapses runs of whitespace, so re-indenting a line does
+// not change its fingerprint.
+func normalizeSnippet(line string) string {
+	return strings.Join(strings.Fields(line), " ")
+}
+
+// newBaseline records every violation in reports.
+func newBaseline(reports []fileReport) *baseline {
+	b := &baseline{Version: baselineVersion, Entries: []baselineEntry{}}
+	index := make(map[string]int) // fingerprint → position in Entries
+	for _, r := range reports {
+		if r.ValidationResult == nil {
+			continue
+		}
+		lines := strings.Split(r.source, "\n")
+		for _, v := range r.Violations {
+			e := newBaselineEntry(r.file, lines, v)
+			if i, ok := index[e.Fingerprint]; ok {
+				b.Entries[i].Count++
+				continue
+			}
+			index[e.Fingerprint] = len(b.Entries)
+			b.Entries = append(b.Entries, e)
+		}
+	}
+
+	sort.SliceStable(b.Entries, func(i, j int) bool {
+		ei, ej := b.Entries[i], b.Entries[j]
+		if ei.File != ej.File {
+			return ei.File < ej.File
+		}
+		return ei.Rule < ej.Rule
+	})
+	return b
+}
+
+// loadBaseline reads a baseline file written by --baseline-write.
+func loadBaseline(path string) (*baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read baseline: %w", err)
+	}
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("baseline %s has unsupported version %d (expected %d)", path, b.Version, baselineVersion)
+	}
+	return &b, nil
+}
+
+// write saves the baseline to path, creating its directory if needed.
+func (b *baseline) write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// apply removes the violations recorded in the baseline from reports and
+// lists the entries that no longer occur. An entry only counts as fixed when
+// its file was validated in this run or no longer exists, so validating part
+// of a project does not report the rest of the baseline as fixed. Entry paths
+// are relative to root, the project root.
+func (b *baseline) apply(reports []fileReport, root string) baselineResult {
+	remaining := make(map[string]int, len(b.Entries))
+	for _, e := range b.Entries {
+		remaining[e.Fingerprint] += e.Count
+	}
+
+	var result baselineResult
+	checked := make(map[string]bool)
+	for _, r := range reports {
+		if r.ValidationResult == nil {
+			continue
+		}
+		checked[r.file] = true
+		lines := strings.Split(r.source, "\n")
+		r.Retain(func(v validator.Violation) bool {
+			fp := newBaselineEntry(r.file, lines, v).Fingerprint
+			if remaining[fp] == 0 {
+				return true
+			}
+			remaining[fp]--
+			result.Matched++
+			return false
+		})
+	}
+
+	for _, e := range b.Entries {
+		n := remaining[e.Fingerprint]
+		if n == 0 {
+			continue
+		}
+		remaining[e.Fingerprint] = 0
+		path := filepath.FromSlash(e.File)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+		if !checked[e.File] && !fileMissing(path) {
+			continue
+		}
+		e.Count = n
+		result.Fixed = append(result.Fixed, e)
+	}
+	return result
+}
+
+// fileMissing reports whether path does not exist.
+func fileMissing(path string) bool {
+	_, err := os.Stat(path)
+	return os.IsNotExist(err)
+}
+
+// printBaselineResult tells the user how many violations the baseline hid
+// and which of its entries have been fixed.
+func printBaselineResult(w io.Writer, path string, result baselineResult) {
+	if result.Matched > 0 {
+		fmt.Fprintf(w, "%d known violation(s) hidden by baseline %s\n", result.Matched, path)
+	}
+	if len(result.Fixed) == 0 {
+		return
+	}
+	fixed := 0
+	for _, e := range result.Fixed {
+		fixed += e.Count
+	}
+	fmt.Fprintf(w, "%d baseline violation(s) fixed — run with --baseline-write %s to update the baseline:\n", fixed, path)
+	for _, e := range result.Fixed {
+		fmt.Fprintf(w, "  %s  %s  %s\n", e.File, e.Rule, e.Snippet)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnana997/uispec/pkg/validator"
+)
+
+func baselineTestReport(source string, violations ...validator.Violation) fileReport {
+	return fileReport{
+		Path:             "src/page.tsx",
+		file:             "src/page.tsx",
+		source:           source,
+		ValidationResult: &validator.ValidationResult{Valid: false, Violations: violations},
+	}
+}
+
+func TestBaseline_RoundTrip(t *testing.T) {
+	source := "<Buton />\n<Buton />\n  <Card  variant=\"x\" />\n"
+	reports := []fileReport{baselineTestReport(source,
+		validator.Violation{Rule: "unknown-component", Component: "Buton", Severity: "error", Line: 1},
+		validator.Violation{Rule: "unknown-component", Component: "Buton", Severity: "error", Line: 2},
+		validator.Violation{Rule: "unknown-prop", Component: "Card", Severity: "info", Line: 3},
+	)}
+
+	b := newBaseline(reports)
+	require.Len(t, b.Entries, 2)
+	assert.Equal(t, "unknown-component", b.Entries[0].Rule)
+	assert.Equal(t, 2, b.Entries[0].Count)
+	assert.Equal(t, `<Card variant="x" />`, b.Entries[1].Snippet)
+
+	path := filepath.Join(t.TempDir(), ".uispec", "baseline.json")
+	require.NoError(t, b.write(path))
+	loaded, err := loadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, b, loaded)
+}
+
+func TestBaseline_Apply(t *testing.T) {
+	old := "<Buton />\n<Card variant=\"x\" />\n"
+	b := newBaseline([]fileReport{baselineTestReport(old,
+		validator.Violation{Rule: "unknown-component", Component: "Buton", Severity: "error", Line: 1},
+		validator.Violation{Rule: "unknown-prop", Component: "Card", Severity: "info", Line: 2},
+	)})
+
+	// Lines have shifted, the Card violation is fixed and a new one appeared.
+	current := "import x from \"y\"\n\n    <Buton />\n<Buton />\n"
+	reports := []fileReport{baselineTestReport(current,
+		validator.Violation{Rule: "unknown-component", Component: "Buton", Severity: "error", Line: 3},
+		validator.Violation{Rule: "unknown-component", Component: "Buton", Severity: "error", Line: 4},
+	)}
+
+	result := b.apply(reports, t.TempDir())
+
+	assert.Equal(t, 1, result.Matched)
+	require.Len(t, reports[0].Violations, 1)
+	assert.Equal(t, 4, reports[0].Violations[0].Line)
+	assert.False(t, reports[0].Valid)
+	require.Len(t, result.Fixed, 1)
+	assert.Equal(t, "unknown-prop", result.Fixed[0].Rule)
+
+	var out bytes.Buffer
+	printBaselineResult(&out, ".uispec/baseline.json", result)
+	assert.Contains(t, out.String(), "1 known violation(s) hidden by baseline .uispec/baseline.json")
+	assert.Contains(t, out.String(), "1 baseline violation(s) fixed")
+}
+
+func TestBaseline_ApplyIgnoresUncheckedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, "other.tsx")
+	b := &baseline{Version: baselineVersion, Entries: []baselineEntry{
+		{Fingerprint: "a", Rule: "unknown-prop", File: filepath.ToSlash(filepath.Join(root, "other.tsx")), Count: 1},
+		{Fingerprint: "b", Rule: "unknown-prop", File: filepath.ToSlash(filepath.Join(root, "deleted.tsx")), Count: 1},
+	}}
+
+	result := b.apply(nil, root)
+
+	require.Len(t, result.Fixed, 1)
+	assert.Equal(t, "b", result.Fixed[0].Fingerprint)
+}
+
+func TestBaseline_ProjectRelativePaths(t *testing.T) {
+	// Run from a subdirectory, the report shows a path relative to it.
+	report := baselineTestReport("<Buton />\n",
+		validator.Violation{Rule: "unknown-component", Component: "Buton", Severity: "error", Line: 1})
+	report.Path = "page.tsx"
+	b := newBaseline([]fileReport{report})
+
+	require.Len(t, b.Entries, 1)
+	assert.Equal(t, "src/page.tsx", b.Entries[0].File)
+	assert.Equal(t, newBaseline([]fileReport{baselineTestReport("<Buton />\n",
+		validator.Violation{Rule: "unknown-component", Component: "Buton", Severity: "error", Line: 1})}), b)
+
+	root := t.TempDir()
+	writeTree(t, root, "src/page.tsx")
+	assert.Empty(t, b.apply(nil, root).Fixed, "the entry's file exists under the root")
+}
+
+func TestLoadBaseline_Errors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := loadBaseline(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "baseline.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 2, "entries": []}`), 0644))
+	_, err = loadBaseline(path)
+	assert.ErrorContains(t, err, "unsupported version 2")
+}
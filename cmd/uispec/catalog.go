@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gnana997/uispec/pkg/catalog"
+	"github.com/gnana997/uispec/pkg/parser"
+	"github.com/gnana997/uispec/pkg/validator"
+)
+
+// catalogCheckReport is the JSON output of `uispec catalog check`.
+type catalogCheckReport struct {
+	Valid    bool     `json:"valid"`
+	Examples int      `json:"examples"`
+	Errors   []string `json:"errors"`
+}
+
+// runCatalog is the entry point for `uispec catalog`.
+func runCatalog(args []string) {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "usage: uispec catalog check [--catalog path] [--json]")
+		os.Exit(exitFailure)
+	}
+
+	catalogFlag := ""
+	asJSON := false
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--catalog":
+			if i+1 < len(args) {
+				i++
+				catalogFlag = args[i]
+			}
+		case "--json":
+			asJSON = true
+		}
+	}
+
+	// Loading runs Catalog.Validate, so structural errors stop here.
+	qs, err := loadCatalog(resolveCatalogPath(catalogFlag))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitFailure)
+	}
+
+	rules, err := loadRuleConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitFailure)
+	}
+
+	pm := parser.NewParserManager(nil)
+	v := validator.NewValidator(qs.Catalog, qs.Index, pm)
+	v.SetRuleConfig(rules)
+	v.SetTransparentComponents(transparentComponents())
+
+	code := executeCatalogCheck(os.Stdout, qs.Catalog, exampleCheck(v), asJSON)
+	_ = pm.Close()
+	os.Exit(code)
+}
+
+// exampleCheck checks an example page the way validate_page would: the
+// example fails on the violations that make the page invalid.
+func exampleCheck(v *validator.Validator) catalog.ExampleCheck {
+	return func(page string) []catalog.ExampleIssue {
+		var issues []catalog.ExampleIssue
+		for _, viol := range v.ValidatePage(page, false).Violations {
+			if viol.Severity == "error" {
+				issues = append(issues, catalog.ExampleIssue{
+					Line:    viol.Line,
+					Message: fmt.Sprintf("%s (%s)", viol.Message, viol.Rule),
+				})
+			}
+		}
+		return issues
+	}
+}
+
+// executeCatalogCheck validates the catalog's examples, prints the result to
+// w, and returns the process exit code.
+func executeCatalogCheck(w io.Writer, cat *catalog.Catalog, check catalog.ExampleCheck, asJSON bool) int {
+	errs := cat.ValidateExamples(check)
+
+	examples := 0
+	for _, comp := range cat.Components {
+		examples += len(comp.Examples)
+	}
+	report := catalogCheckReport{Valid: len(errs) == 0, Examples: examples, Errors: []string{}}
+	for _, err := range errs {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to encode result: %v\n", err)
+			return exitFailure
+		}
+	} else if report.Valid {
+		fmt.Fprintf(w, "✓ %s — %d example(s) pass\n", cat.Name, examples)
+	} else {
+		fmt.Fprintf(w, "✗ %s — %d problem(s) in %d example(s)\n", cat.Name, len(errs), examples)
+		fmt.Fprintf(w, "  %s\n", strings.Join(report.Errors, "\n  "))
+	}
+
+	if !report.Valid {
+		return exitViolations
+	}
+	return exitValid
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnana997/uispec/pkg/catalog"
+)
+
+func TestExecuteCatalogCheck(t *testing.T) {
+	cat := &catalog.Catalog{
+		Name: "test",
+		Components: []catalog.Component{{
+			Name:          "Button",
+			ImportPath:    "@/components/ui/button",
+			ImportedNames: []string{"Button"},
+			Examples: []catalog.Example{
+				{Title: "Default", Code: `<Button>Go</Button>`},
+				{Title: "Broken", Code: `<Button variant="fancy">Go</Button>`},
+			},
+		}},
+	}
+	check := func(page string) []catalog.ExampleIssue {
+		if strings.Contains(page, "fancy") {
+			return []catalog.ExampleIssue{{Line: 6, Message: "bad variant (invalid-prop-value)"}}
+		}
+		return nil
+	}
+
+	var out bytes.Buffer
+	code := executeCatalogCheck(&out, cat, check, false)
+	assert.Equal(t, exitViolations, code)
+	assert.Equal(t, "✗ test — 1 problem(s) in 2 example(s)\n"+
+		"  component \"Button\" example \"Broken\" line 1: bad variant (invalid-prop-value)\n", out.String())
+
+	out.Reset()
+	code = executeCatalogCheck(&out, cat, check, true)
+	assert.Equal(t, exitViolations, code)
+	var report catalogCheckReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.False(t, report.Valid)
+	assert.Equal(t, 2, report.Examples)
+	assert.Len(t, report.Errors, 1)
+
+	out.Reset()
+	cat.Components[0].Examples = cat.Components[0].Examples[:1]
+	assert.Equal(t, exitValid, executeCatalogCheck(&out, cat, check, false))
+	assert.Equal(t, "✓ test — 1 example(s) pass\n", out.String())
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gnana997/uispec/pkg/validator"
+)
+
+// lineRange is an inclusive range of 1-based line numbers.
+type lineRange struct {
+	start, end int
+}
+
+// changeSet maps the real path of each changed file to its changed lines.
+// A file that only moved or changed mode is present with no ranges.
+type changeSet map[string][]lineRange
+
+// gitChanges returns the lines that differ between the merge base of ref and
+// HEAD and the working tree of the repository containing dir, so both
+// committed and uncommitted work count. Untracked files count as changed
+// throughout. Only the local git binary is used.
+func gitChanges(dir, ref string) (changeSet, error) {
+	root, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root = realPath(strings.TrimSpace(root))
+
+	base, err := runGit(dir, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	diff, err := runGit(dir, "-c", "core.quotePath=false", "diff", "--no-color", "--no-ext-diff", "--unified=0", "--find-renames",
+		"--src-prefix=a/", "--dst-prefix=b/", strings.TrimSpace(base), "--")
+	if err != nil {
+		return nil, err
+	}
+	changes := parseUnifiedDiff(root, diff)
+
+	untracked, err := runGit(dir, "ls-files", "--others", "--exclude-standard", "-z", "--full-name", ":/")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(untracked, "\x00") {
+		if name != "" {
+			changes[filepath.Join(root, filepath.FromSlash(name))] = []lineRange{{1, math.MaxInt}}
+		}
+	}
+	return changes, nil
+}
+
+// runGit runs git in dir and returns its standard output.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s failed: %s", strings.Join(args, " "), msg)
+		}
+		return "", fmt.Errorf("git %s failed: %w", strings.Join(args, " "), err)
+	}
+	return stdout.String(), nil
+}
+
+// parseUnifiedDiff collects the new-side line ranges of each file in a
+// `git diff --unified=0` patch. Paths are joined to root.
+func parseUnifiedDiff(root, diff string) changeSet {
+	changes := make(changeSet)
+	current := ""
+	inHeader := false // between "diff --git" and the first hunk, where +++ names the file
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			inHeader = true
+			current = ""
+
+		case inHeader && strings.HasPrefix(line, "+++ "):
+			name := strings.TrimPrefix(line, "+++ ")
+			if name == "/dev/null" {
+				continue // deleted
+			}
+			if unquoted, err := strconv.Unquote(name); err == nil {
+				name = unquoted
+			}
+			current = filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(name, "b/")))
+			if _, ok := changes[current]; !ok {
+				changes[current] = nil
+			}
+
+		case inHeader && strings.HasPrefix(line, "rename to "):
+			// A pure rename has no +++ line.
+			current = filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(line, "rename to ")))
+			if _, ok := changes[current]; !ok {
+				changes[current] = nil
+			}
+
+		case strings.HasPrefix(line, "@@ "):
+			inHeader = false
+			if r, ok := parseHunkHeader(line); ok && current != "" {
+				changes[current] = append(changes[current], r)
+			}
+		}
+	}
+	return changes
+}
+
+// parseHunkHeader returns the new-side lines of a hunk header such as
+// "@@ -3,2 +4,5 @@". A pure deletion has no new lines, so the lines on either
+// side of it count as changed.
+func parseHunkHeader(line string) (lineRange, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return lineRange{}, false
+	}
+	start, count := strings.TrimPrefix(fields[2], "+"), "1"
+	if i := strings.IndexByte(start, ','); i >= 0 {
+		start, count = start[:i], start[i+1:]
+	}
+	s, err := strconv.Atoi(start)
+	if err != nil {
+		return lineRange{}, false
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return lineRange{}, false
+	}
+	if n == 0 {
+		return lineRange{max(s, 1), s + 1}, true
+	}
+	return lineRange{s, s + n - 1}, true
+}
+
+// filter returns the files that changed, in their original order.
+func (c changeSet) filter(files []string) []string {
+	var out []string
+	for _, f := range files {
+		if _, ok := c[realPath(f)]; ok {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// touches reports whether any of the lines start to end of file is within a
+// changed range.
+func (c changeSet) touches(file string, start, end int) bool {
+	for _, r := range c[realPath(file)] {
+		if start <= r.end && end >= r.start {
+			return true
+		}
+	}
+	return false
+}
+
+// covers reports whether a violation in file is about changed code: some line
+// of the element it concerns has changed. Parse errors have no useful line
+// and are always covered.
+func (c changeSet) covers(file string, v validator.Violation) bool {
+	return v.Rule == "parse-error" || c.touches(file, v.Line, max(v.EndLine, v.Line))
+}
+
+// realPath resolves symlinks in path, so it can be compared with the paths
+// git reports. It returns path unchanged if it cannot be resolved.
+func realPath(path string) string {
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		return real
+	}
+	return path
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnana997/uispec/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHunkHeader(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected lineRange
+	}{
+		{"@@ -3,2 +4,5 @@ export function Page() {", lineRange{4, 8}},
+		{"@@ -3 +3 @@", lineRange{3, 3}},
+		{"@@ -0,0 +1,12 @@", lineRange{1, 12}},
+		{"@@ -7,2 +6,0 @@", lineRange{6, 7}},
+		{"@@ -1 +0,0 @@", lineRange{1, 1}},
+	}
+	for _, tt := range tests {
+		r, ok := parseHunkHeader(tt.header)
+		require.True(t, ok, tt.header)
+		assert.Equal(t, tt.expected, r, tt.header)
+	}
+
+	_, ok := parseHunkHeader("@@ garbage @@")
+	assert.False(t, ok)
+}
+
+func TestParseUnifiedDiff(t *testing.T) {
+	diff := `diff --git a/src/page.tsx b/src/page.tsx
+index 1111111..2222222 100644
+--- a/src/page.tsx
++++ b/src/page.tsx
+@@ -2,0 +3,2 @@ import { Button } from "@/components/ui/button"
++++counter
++<Button />
+@@ -10 +12 @@
+-<Card>
++<Card className="p-4">
+diff --git a/src/old.tsx b/src/old.tsx
+deleted file mode 100644
+--- a/src/old.tsx
++++ /dev/null
+@@ -1 +0,0 @@
+-<Old />
+diff --git a/src/a.tsx b/src/b.tsx
+similarity index 100%
+rename from src/a.tsx
+rename to src/b.tsx
+`
+	changes := parseUnifiedDiff("/repo", diff)
+
+	assert.Equal(t, changeSet{
+		filepath.Join("/repo", "src", "page.tsx"): {{3, 4}, {12, 12}},
+		filepath.Join("/repo", "src", "b.tsx"):    nil,
+	}, changes)
+}
+
+func TestChangeSet(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, "a.tsx", "b.tsx")
+	a, b := filepath.Join(root, "a.tsx"), filepath.Join(root, "b.tsx")
+	changes := changeSet{realPath(a): {{3, 4}, {10, math.MaxInt}}}
+
+	assert.Equal(t, []string{a}, changes.filter([]string{a, b}))
+	assert.True(t, changes.touches(a, 4, 4))
+	assert.True(t, changes.touches(a, 200, 200))
+	assert.False(t, changes.touches(a, 5, 5))
+	assert.False(t, changes.touches(b, 3, 3))
+	assert.True(t, changes.touches(a, 1, 3), "a range overlapping a change")
+	assert.False(t, changes.touches(a, 5, 9))
+
+	assert.True(t, changes.covers(a, validator.Violation{Line: 6, EndLine: 12}), "an element ending in the change")
+	assert.False(t, changes.covers(a, validator.Violation{Line: 6}))
+	assert.True(t, changes.covers(b, validator.Violation{Rule: "parse-error", Line: 1}))
+}
+
+// git runs a git command in dir for test setup.
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestGitChanges(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0644))
+	}
+
+	git(t, root, "init", "-q", "-b", "main")
+	write("page.tsx", "one\ntwo\nthree\n")
+	write("same.tsx", "same\n")
+	git(t, root, "add", ".")
+	git(t, root, "commit", "-q", "-m", "base")
+
+	git(t, root, "checkout", "-q", "-b", "feature")
+	write("page.tsx", "one\nTWO\nthree\n")
+	git(t, root, "commit", "-q", "-am", "change")
+	write("page.tsx", "one\nTWO\nthree\nfour\n") // uncommitted
+	write("new.tsx", "new\n")                    // untracked
+
+	changes, err := gitChanges(root, "main")
+	require.NoError(t, err)
+
+	page := filepath.Join(root, "page.tsx")
+	assert.True(t, changes.touches(page, 2, 2))
+	assert.True(t, changes.touches(page, 4, 4))
+	assert.False(t, changes.touches(page, 3, 3))
+	assert.True(t, changes.touches(filepath.Join(root, "new.tsx"), 1, 1))
+	assert.Equal(t, []string{page}, changes.filter([]string{page, filepath.Join(root, "same.tsx")}))
+
+	// Prefix settings in the user's config don't change the paths.
+	git(t, root, "config", "diff.noprefix", "true")
+	noPrefix, err := gitChanges(root, "main")
+	require.NoError(t, err)
+	assert.Equal(t, changes, noPrefix)
+	git(t, root, "config", "diff.mnemonicPrefix", "true")
+	git(t, root, "config", "diff.noprefix", "false")
+	mnemonic, err := gitChanges(root, "main")
+	require.NoError(t, err)
+	assert.Equal(t, changes, mnemonic)
+
+	_, err = gitChanges(root, "no-such-ref")
+	assert.ErrorContains(t, err, "git merge-base")
+}
@@ -1,14 +1,17 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/gnana997/uispec/catalogs"
 	"github.com/gnana997/uispec/pkg/catalog"
+	"github.com/gnana997/uispec/pkg/validator"
 )
 
 // ProjectConfig holds the contents of .uispec/config.yaml.
@@ -16,12 +19,32 @@ type ProjectConfig struct {
 	Version     string `yaml:"version"`
 	Framework   string `yaml:"framework"`
 	CatalogPath string `yaml:"catalog_path"`
+
+	// Rules sets validator rule levels: off, info, warning, or error.
+	Rules map[string]string `yaml:"rules,omitempty"`
+	// Overrides applies rule levels to files matching globs.
+	Overrides []RuleOverrideConfig `yaml:"overrides,omitempty"`
+	// TransparentComponents are wrapper components (e.g. local layout
+	// components) that composition rules look through. Fragment is always
+	// transparent.
+	TransparentComponents []string `yaml:"transparent_components,omitempty"`
+}
+
+// RuleOverrideConfig is one entry of the overrides list in config.yaml:
+//
+//	overrides:
+//	  - files: ["src/legacy/**"]
+//	    rules:
+//	      invalid-prop-value: off
+type RuleOverrideConfig struct {
+	Files []string          `yaml:"files"`
+	Rules map[string]string `yaml:"rules"`
 }
 
-// loadProjectConfig reads .uispec/config.yaml from the current directory.
+// loadProjectConfig reads .uispec/config.yaml from the project root.
 // Returns nil (no error) if the file does not exist.
 func loadProjectConfig() (*ProjectConfig, error) {
-	data, err := os.ReadFile(".uispec/config.yaml")
+	data, err := os.ReadFile(filepath.Join(projectRoot(), ".uispec", "config.yaml"))
 	if os.IsNotExist(err) {
 		return nil, nil
 	}
@@ -35,6 +58,79 @@ func loadProjectConfig() (*ProjectConfig, error) {
 	return &cfg, nil
 }
 
+// projectRoot returns the directory containing the project's .uispec
+// directory: the working directory or its nearest parent with one. Without
+// one it is the working directory. Override globs, catalog_path, and baseline
+// entries are relative to it.
+func projectRoot() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if info, err := os.Stat(filepath.Join(dir, ".uispec")); err == nil && info.IsDir() {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			return wd
+		}
+	}
+}
+
+// projectPath returns path relative to root with forward slashes, or
+// absolute when it is outside root.
+func projectPath(root, path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(abs)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// ruleConfig converts the project's rules and overrides into a validator
+// RuleConfig. Returns nil when no rules are configured.
+func (c *ProjectConfig) ruleConfig() *validator.RuleConfig {
+	if c == nil || (len(c.Rules) == 0 && len(c.Overrides) == 0) {
+		return nil
+	}
+	cfg := &validator.RuleConfig{Rules: c.Rules}
+	for _, o := range c.Overrides {
+		cfg.Overrides = append(cfg.Overrides, validator.RuleOverride{Files: o.Files, Rules: o.Rules})
+	}
+	return cfg
+}
+
+// loadRuleConfig reads rule settings from .uispec/config.yaml and validates them.
+// Returns nil (no error) if there is no config file or it configures no rules.
+func loadRuleConfig() (*validator.RuleConfig, error) {
+	cfg, err := loadProjectConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .uispec/config.yaml: %w", err)
+	}
+	rules := cfg.ruleConfig()
+	if rules == nil {
+		return nil, nil
+	}
+	if errs := rules.Validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid rules in .uispec/config.yaml: %w", errors.Join(errs...))
+	}
+	return rules, nil
+}
+
+// transparentComponents returns the transparent_components list from
+// .uispec/config.yaml, or nil if there is none. Read errors are reported by
+// loadRuleConfig.
+func transparentComponents() []string {
+	if cfg, err := loadProjectConfig(); err == nil && cfg != nil {
+		return cfg.TransparentComponents
+	}
+	return nil
+}
+
 // resolveCatalogPath returns the catalog path to use, applying the fallback chain:
 //  1. Explicit --catalog flag value (non-empty override)
 //  2. catalog_path from .uispec/config.yaml
@@ -44,7 +140,10 @@ func resolveCatalogPath(flagValue string) string {
 		return flagValue
 	}
 	if cfg, err := loadProjectConfig(); err == nil && cfg != nil && cfg.CatalogPath != "" {
-		return cfg.CatalogPath
+		if filepath.IsAbs(cfg.CatalogPath) {
+			return cfg.CatalogPath
+		}
+		return filepath.Join(projectRoot(), cfg.CatalogPath)
 	}
 	return ""
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestProjectConfig_RuleConfig(t *testing.T) {
+	data := `
+version: "1"
+catalog_path: .uispec/catalogs/shadcn.json
+rules:
+  invalid-prop-value: error
+  unknown-prop: off
+overrides:
+  - files: ["src/legacy/**"]
+    rules:
+      invalid-prop-value: warning
+`
+	var cfg ProjectConfig
+	require.NoError(t, yaml.Unmarshal([]byte(data), &cfg))
+
+	rules := cfg.ruleConfig()
+	require.NotNil(t, rules)
+	assert.Equal(t, "error", rules.Rules["invalid-prop-value"])
+	assert.Equal(t, "off", rules.Rules["unknown-prop"])
+	require.Len(t, rules.Overrides, 1)
+	assert.Equal(t, []string{"src/legacy/**"}, rules.Overrides[0].Files)
+	assert.Empty(t, rules.Validate())
+}
+
+func TestProjectConfig_NoRules(t *testing.T) {
+	cfg := &ProjectConfig{Version: "1"}
+	assert.Nil(t, cfg.ruleConfig())
+
+	var missing *ProjectConfig
+	assert.Nil(t, missing.ruleConfig())
+}
+
+func TestLoadRuleConfig_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".uispec"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".uispec/config.yaml"), []byte("rules:\n  unknown-prop: loud\n"), 0644))
+	t.Chdir(dir)
+
+	_, err := loadRuleConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid level")
+}
+
+func TestTransparentComponents(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	assert.Nil(t, transparentComponents())
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".uispec"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".uispec/config.yaml"), []byte("transparent_components: [PageSection, Stack]\n"), 0644))
+	assert.Equal(t, []string{"PageSection", "Stack"}, transparentComponents())
+}
+
+func TestProjectRoot(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".uispec"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".uispec/config.yaml"), []byte("catalog_path: .uispec/catalogs/app.json\n"), 0644))
+	sub := filepath.Join(dir, "src", "pages")
+	require.NoError(t, os.MkdirAll(sub, 0755))
+	t.Chdir(sub)
+
+	assert.Equal(t, dir, projectRoot(), "found from a subdirectory")
+	assert.Equal(t, filepath.Join(dir, ".uispec", "catalogs", "app.json"), resolveCatalogPath(""))
+	assert.Equal(t, "src/pages/home.tsx", projectPath(dir, "home.tsx"))
+	assert.Equal(t, filepath.ToSlash(filepath.Dir(dir)), projectPath(dir, filepath.Dir(dir)), "outside the root")
+}
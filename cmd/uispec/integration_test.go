@@ -385,3 +385,16 @@ export default function Page() {
 	require.True(t, ok)
 	assert.Greater(t, len(comps), 0)
 }
+
+func TestIntegration_CatalogCheck(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	// Every bundled example must pass validate_page.
+	out, err := exec.Command(binaryPath, "catalog", "check", "--json").Output()
+	require.NoError(t, err, string(out))
+
+	var report catalogCheckReport
+	require.NoError(t, json.Unmarshal(out, &report))
+	assert.True(t, report.Valid, "%v", report.Errors)
+	assert.Greater(t, report.Examples, 0)
+}
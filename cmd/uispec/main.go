@@ -51,6 +51,8 @@ func main() {
 		runValidate(os.Args[2:])
 	case "inspect":
 		runInspect(os.Args[2:])
+	case "catalog":
+		runCatalog(os.Args[2:])
 	case "serve":
 		runServe(os.Args[2:])
 	case "setup":
@@ -100,9 +102,17 @@ func runServe(args []string) {
 		os.Exit(1)
 	}
 
+	rules, err := loadRuleConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
 	pm := parser.NewParserManager(nil)
 	defer func() { _ = pm.Close() }()
 	v := validator.NewValidator(qs.Catalog, qs.Index, pm)
+	v.SetRuleConfig(rules)
+	v.SetTransparentComponents(transparentComponents())
 
 	var logger *mcplog.Logger
 	if logFile != "" {
@@ -123,93 +133,6 @@ func runServe(args []string) {
 	}
 }
 
-func runValidate(args []string) {
-	var filePath, catalogFlag string
-	autoFix := false
-	asJSON := false
-
-	for i := 0; i < len(args); i++ {
-		switch args[i] {
-		case "--catalog":
-			if i+1 < len(args) {
-				i++
-				catalogFlag = args[i]
-			}
-		case "--fix":
-			autoFix = true
-		case "--json":
-			asJSON = true
-		default:
-			if !strings.HasPrefix(args[i], "--") {
-				filePath = args[i]
-			}
-		}
-	}
-
-	if filePath == "" {
-		fmt.Fprintln(os.Stderr, "usage: uispec validate <file.tsx> [--catalog path] [--fix] [--json]")
-		os.Exit(1)
-	}
-
-	code, err := os.ReadFile(filePath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "cannot read file: %v\n", err)
-		os.Exit(1)
-	}
-
-	catalogPath := resolveCatalogPath(catalogFlag)
-	qs, err := loadCatalog(catalogPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
-	}
-
-	pm := parser.NewParserManager(nil)
-	defer func() { _ = pm.Close() }()
-	v := validator.NewValidator(qs.Catalog, qs.Index, pm)
-
-	result := v.ValidatePage(string(code), autoFix)
-
-	if asJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		if err := enc.Encode(result); err != nil {
-			fmt.Fprintf(os.Stderr, "failed to encode result: %v\n", err)
-			os.Exit(1)
-		}
-		if !result.Valid {
-			os.Exit(2)
-		}
-		return
-	}
-
-	// Human-readable output.
-	if result.Valid {
-		fmt.Printf("✓ %s — no violations\n", filePath)
-	} else {
-		fmt.Printf("✗ %s — %d violation(s)\n", filePath, len(result.Violations))
-		for _, viol := range result.Violations {
-			sev := strings.ToUpper(viol.Severity[:1]) + viol.Severity[1:]
-			fmt.Printf("  [%s] line %d:%d  %s  (%s)\n", sev, viol.Line, viol.Column, viol.Message, viol.Rule)
-			if viol.Suggestion != "" {
-				fmt.Printf("         → %s\n", viol.Suggestion)
-			}
-		}
-	}
-
-	if autoFix && len(result.Fixes) > 0 {
-		fmt.Printf("\n%d fix(es) applied — writing %s\n", len(result.Fixes), filePath)
-		if err := os.WriteFile(filePath, []byte(result.FixedCode), 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "failed to write fixed file: %v\n", err)
-			os.Exit(1)
-		}
-	}
-
-	if !result.Valid {
-		os.Exit(2)
-	}
-}
-
 func runInit(args []string) {
 	preset := "shadcn" // default preset
 	catalogFlag := ""
@@ -478,10 +401,12 @@ func printUsage() {
 	fmt.Println("             --auto            Configure all detected with defaults")
 	fmt.Println("  inspect    Inspect a component's props and usage")
 	fmt.Println("             <Component> [--catalog path] [--json] [--examples]")
+	fmt.Println("  catalog    Check the catalog")
+	fmt.Println("             check [--catalog path] [--json]   Validate every example against the catalog")
 	fmt.Println("  scan       Scan component library and generate catalog")
 	fmt.Println("             <directory> [--output path] [--name name] [--import-prefix prefix]")
 	fmt.Println("  validate   Validate code against catalog")
-	fmt.Println("             <file.tsx> [--catalog path] [--fix] [--json]")
+	fmt.Println("             <file|dir|glob>... [--catalog path] [--write] [--diff] [--baseline path] [--baseline-write path] [--changed-since ref] [--language ext] [--json] [--format text|json|sarif]")
 	fmt.Println("  serve      Start MCP server")
 	fmt.Println("             --catalog <path>      Use a custom catalog path")
 	fmt.Println("             --log                 Log MCP calls to .uispec/logs/mcp.jsonl")
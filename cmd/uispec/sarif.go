@@ -0,0 +1,283 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/gnana997/uispec/pkg/validator"
+)
+
+// SARIF 2.1.0 output for code-scanning UIs and review bots.
+// Only the subset of the schema that uispec populates is modelled here.
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifInfoURI = "https://github.com/gnana997/uispec"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string          `json:"id"`
+	ShortDescription     sarifMessage    `json:"shortDescription"`
+	DefaultConfiguration sarifRuleConfig `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Fixes      []sarifFix        `json:"fixes,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+// writeValidateSARIF encodes the file reports as a SARIF 2.1.0 log.
+func writeValidateSARIF(w io.Writer, reports []fileReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(buildSARIF(reports))
+}
+
+// buildSARIF converts validation reports into a single-run SARIF log.
+// Every built-in rule is declared in the driver; unknown rule ids are appended.
+func buildSARIF(reports []fileReport) sarifLog {
+	var rules []sarifRule
+	ruleIndex := make(map[string]int)
+	addRule := func(info validator.RuleInfo) int {
+		if idx, ok := ruleIndex[info.ID]; ok {
+			return idx
+		}
+		ruleIndex[info.ID] = len(rules)
+		rules = append(rules, sarifRule{
+			ID:                   info.ID,
+			ShortDescription:     sarifMessage{Text: info.Description},
+			DefaultConfiguration: sarifRuleConfig{Level: sarifLevel(info.Severity)},
+		})
+		return ruleIndex[info.ID]
+	}
+	for _, info := range validator.Rules() {
+		addRule(info)
+	}
+
+	results := make([]sarifResult, 0)
+	for _, r := range reports {
+		if r.ValidationResult == nil {
+			continue
+		}
+		artifact := sarifArtifact(r.Path)
+		lines := strings.Split(r.source, "\n")
+
+		for vi, viol := range r.Violations {
+			info, ok := validator.LookupRule(viol.Rule)
+			if !ok {
+				info = validator.RuleInfo{ID: viol.Rule, Description: viol.Rule, Severity: viol.Severity}
+			}
+
+			text := viol.Message
+			if viol.Suggestion != "" {
+				text += " — " + viol.Suggestion
+			}
+
+			result := sarifResult{
+				RuleID:    viol.Rule,
+				RuleIndex: addRule(info),
+				Level:     sarifLevel(viol.Severity),
+				Message:   sarifMessage{Text: text},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: artifact,
+						Region: sarifRegion{
+							StartLine:   viol.Line,
+							StartColumn: utf16Column(lineAt(lines, viol.Line), viol.Column),
+						},
+					},
+				}},
+			}
+			if viol.Component != "" || viol.Suggestion != "" {
+				result.Properties = map[string]string{}
+				if viol.Component != "" {
+					result.Properties["component"] = viol.Component
+				}
+				if viol.Suggestion != "" {
+					result.Properties["suggestion"] = viol.Suggestion
+				}
+			}
+
+			for _, fix := range r.Fixes {
+				if fix.Violation != vi {
+					continue
+				}
+				result.Fixes = append(result.Fixes, sarifFixFor(fix, artifact, lines))
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "uispec",
+				Version:        version,
+				InformationURI: sarifInfoURI,
+				Rules:          rules,
+			}},
+			ColumnKind: "utf16CodeUnits",
+			Results:    results,
+		}},
+	}
+}
+
+// sarifFixFor converts an AutoFix into a SARIF fix replacing its range;
+// insertions have an empty deleted region.
+func sarifFixFor(fix validator.AutoFix, artifact sarifArtifactLocation, lines []string) sarifFix {
+	repl := sarifReplacement{
+		DeletedRegion: sarifRegion{
+			StartLine:   fix.Line,
+			StartColumn: utf16Column(lineAt(lines, fix.Line), fix.Column),
+			EndLine:     fix.EndLine,
+			EndColumn:   utf16Column(lineAt(lines, fix.EndLine), fix.EndColumn),
+		},
+		InsertedContent: sarifMessage{Text: fix.NewText},
+	}
+
+	return sarifFix{
+		Description: sarifMessage{Text: fix.Reason},
+		ArtifactChanges: []sarifArtifactChange{{
+			ArtifactLocation: artifact,
+			Replacements:     []sarifReplacement{repl},
+		}},
+	}
+}
+
+// sarifArtifact returns the artifact location for a report path. Relative
+// paths are resolved against the %SRCROOT% base so that viewers can map them
+// onto the checked-out repository.
+func sarifArtifact(path string) sarifArtifactLocation {
+	uri := filepath.ToSlash(path)
+	if filepath.IsAbs(path) {
+		return sarifArtifactLocation{URI: "file://" + uri}
+	}
+	return sarifArtifactLocation{URI: uri, URIBaseID: "%SRCROOT%"}
+}
+
+// sarifLevel maps a validator severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// lineAt returns the 1-based line from lines, or "" if out of range.
+func lineAt(lines []string, line int) string {
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return lines[line-1]
+}
+
+// utf16Column converts a 1-based byte column on line to a 1-based UTF-16
+// code unit column, as expected by SARIF's default columnKind.
+func utf16Column(line string, byteCol int) int {
+	if byteCol < 1 {
+		return 1
+	}
+	prefix := line
+	if byteCol-1 < len(line) {
+		prefix = line[:byteCol-1]
+	}
+	if !utf8.ValidString(prefix) {
+		return byteCol
+	}
+	return len(utf16.Encode([]rune(prefix))) + 1 + max(0, byteCol-1-len(line))
+}
+
+// parseOutputFormat validates the --format flag value.
+func parseOutputFormat(value string) (string, error) {
+	switch value {
+	case "text", "json", "sarif":
+		return value, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (available: text, json, sarif)", value)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnana997/uispec/pkg/validator"
+)
+
+func sarifTestReport() fileReport {
+	source := "import { Button } from \"wrong/path\"\n\nexport default () => <Button variant=\"fancy\">Go</Button>\n"
+	return fileReport{
+		Path:   "src/page.tsx",
+		source: source,
+		ValidationResult: &validator.ValidationResult{
+			Violations: []validator.Violation{
+				{Rule: "wrong-import-path", Message: "Component \"Button\" is imported from \"wrong/path\"", Severity: "error", Line: 3, Column: 22, Component: "Button", Suggestion: "Change import path to \"@/components/ui/button\""},
+				{Rule: "invalid-prop-value", Message: "Prop \"variant\" has invalid value \"fancy\"", Severity: "warning", Line: 3, Column: 22, Component: "Button"},
+				{Rule: "unknown-prop", Message: "Prop \"x\" is not defined", Severity: "info", Line: 3, Column: 22, Component: "Button"},
+			},
+			Fixes: []validator.AutoFix{
+				{Line: 1, Column: 25, EndLine: 1, EndColumn: 35, StartByte: 24, EndByte: 34, OldText: "wrong/path", NewText: "@/components/ui/button", Rule: "wrong-import-path", Reason: "Fix import path for Button", Component: "Button"},
+			},
+		},
+	}
+}
+
+func TestBuildSARIF_Results(t *testing.T) {
+	log := buildSARIF([]fileReport{sarifTestReport()})
+
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Equal(t, "uispec", run.Tool.Driver.Name)
+	assert.Len(t, run.Tool.Driver.Rules, len(validator.Rules()))
+
+	require.Len(t, run.Results, 3)
+	first := run.Results[0]
+	assert.Equal(t, "wrong-import-path", first.RuleID)
+	assert.Equal(t, "wrong-import-path", run.Tool.Driver.Rules[first.RuleIndex].ID)
+	assert.Equal(t, "error", first.Level)
+	assert.Contains(t, first.Message.Text, "Change import path")
+	assert.Equal(t, "Button", first.Properties["component"])
+
+	loc := first.Locations[0].PhysicalLocation
+	assert.Equal(t, "src/page.tsx", loc.ArtifactLocation.URI)
+	assert.Equal(t, "%SRCROOT%", loc.ArtifactLocation.URIBaseID)
+	assert.Equal(t, 3, loc.Region.StartLine)
+	assert.Equal(t, 22, loc.Region.StartColumn)
+
+	assert.Equal(t, "warning", run.Results[1].Level)
+	assert.Equal(t, "note", run.Results[2].Level)
+}
+
+func TestBuildSARIF_Fixes(t *testing.T) {
+	log := buildSARIF([]fileReport{sarifTestReport()})
+	results := log.Runs[0].Results
+
+	require.Len(t, results[0].Fixes, 1)
+	assert.Empty(t, results[1].Fixes)
+
+	fix := results[0].Fixes[0]
+	assert.Equal(t, "Fix import path for Button", fix.Description.Text)
+	repl := fix.ArtifactChanges[0].Replacements[0]
+	assert.Equal(t, sarifRegion{StartLine: 1, StartColumn: 25, EndLine: 1, EndColumn: 35}, repl.DeletedRegion)
+	assert.Equal(t, "@/components/ui/button", repl.InsertedContent.Text)
+}
+
+func TestBuildSARIF_FixesFollowTheirViolation(t *testing.T) {
+	source := "<Button variant=\"fancy\" />\n<Button variant=\"plain\" />\n"
+	report := fileReport{
+		Path:   "page.tsx",
+		source: source,
+		ValidationResult: &validator.ValidationResult{
+			Violations: []validator.Violation{
+				{Rule: "invalid-prop-value", Severity: "warning", Line: 1, Column: 9, Component: "Button"},
+				{Rule: "invalid-prop-value", Severity: "warning", Line: 2, Column: 9, Component: "Button"},
+			},
+			Fixes: []validator.AutoFix{
+				{Line: 1, Column: 17, EndLine: 1, EndColumn: 24, StartByte: 16, EndByte: 23, OldText: `"fancy"`, NewText: `"default"`, Rule: "invalid-prop-value", Component: "Button", Violation: 0},
+				{Line: 2, Column: 17, EndLine: 2, EndColumn: 24, StartByte: 44, EndByte: 51, OldText: `"plain"`, NewText: `"outline"`, Rule: "invalid-prop-value", Component: "Button", Violation: 1},
+			},
+		},
+	}
+
+	results := buildSARIF([]fileReport{report}).Runs[0].Results
+
+	require.Len(t, results, 2)
+	require.Len(t, results[0].Fixes, 1)
+	require.Len(t, results[1].Fixes, 1)
+	assert.Equal(t, `"default"`, results[0].Fixes[0].ArtifactChanges[0].Replacements[0].InsertedContent.Text)
+	assert.Equal(t, `"outline"`, results[1].Fixes[0].ArtifactChanges[0].Replacements[0].InsertedContent.Text)
+	assert.Equal(t, 2, results[1].Fixes[0].ArtifactChanges[0].Replacements[0].DeletedRegion.StartLine)
+}
+
+func TestBuildSARIF_InsertionFix(t *testing.T) {
+	fix := validator.AutoFix{Line: 2, Column: 1, EndLine: 2, EndColumn: 1, StartByte: 18, EndByte: 18, NewText: "import { Button } from \"@/components/ui/button\"\n", Rule: "missing-import", Reason: "Add missing import"}
+	got := sarifFixFor(fix, sarifArtifact("a.tsx"), []string{"import x from \"y\"", ""})
+
+	repl := got.ArtifactChanges[0].Replacements[0]
+	assert.Equal(t, sarifRegion{StartLine: 2, StartColumn: 1, EndLine: 2, EndColumn: 1}, repl.DeletedRegion)
+	assert.Equal(t, fix.NewText, repl.InsertedContent.Text)
+}
+
+func TestWriteValidateSARIF_ValidJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeValidateSARIF(&buf, []fileReport{sarifTestReport()}))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "2.1.0", decoded["version"])
+	assert.Contains(t, decoded, "$schema")
+}
+
+func TestUTF16Column(t *testing.T) {
+	assert.Equal(t, 5, utf16Column("abcdef", 5))
+	// "é" is 2 bytes in UTF-8 but 1 UTF-16 code unit.
+	assert.Equal(t, 3, utf16Column("éxy", 4))
+	// Astral characters take 4 bytes and 2 UTF-16 code units.
+	assert.Equal(t, 4, utf16Column("😀x", 6))
+}
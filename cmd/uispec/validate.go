@@ -0,0 +1,624 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/gnana997/uispec/pkg/parser"
+	"github.com/gnana997/uispec/pkg/scanner"
+	"github.com/gnana997/uispec/pkg/textdiff"
+	"github.com/gnana997/uispec/pkg/util"
+	"github.com/gnana997/uispec/pkg/validator"
+)
+
+// validateOptions holds parsed flags for the validate command.
+type validateOptions struct {
+	paths       []string
+	catalogFlag string
+	autoFix     bool   // generate fixes; set by --fix, --write and --diff
+	write       bool   // write fixed files back in place
+	diff        bool   // report fixes as unified diffs
+	language    string // grammar for every file; empty picks it by extension
+	format      string // "text", "json", or "sarif"
+
+	baseline      string // hide the violations recorded in this baseline file
+	baselineWrite string // record the current violations to this file instead of reporting them
+	changedSince  string // git ref; report only violations on lines changed since it
+}
+
+// fileReport is the validation outcome for one file.
+type fileReport struct {
+	Path string `json:"path"`
+	*validator.ValidationResult
+	Error string `json:"error,omitempty"`
+
+	source string // original file contents, used to locate fixes in SARIF output
+	file   string // path relative to the project root, for overrides and the baseline
+}
+
+// validateReport aggregates the results of a multi-file validation run.
+type validateReport struct {
+	Valid    bool            `json:"valid"`
+	Files    []fileReport    `json:"files"`
+	Summary  string          `json:"summary"`
+	Baseline *baselineResult `json:"baseline,omitempty"`
+}
+
+// Exit codes for uispec validate.
+const (
+	exitValid      = 0
+	exitFailure    = 1
+	exitViolations = 2
+)
+
+// runValidate is the entry point for `uispec validate`.
+func runValidate(args []string) {
+	opts := parseValidateFlags(args)
+	if len(opts.paths) == 0 && opts.changedSince != "" {
+		opts.paths = []string{"."}
+	}
+	if len(opts.paths) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: uispec validate <file|dir|glob>... [--catalog path] [--write] [--diff] [--baseline path] [--baseline-write path] [--changed-since ref] [--language ext] [--json] [--format text|json|sarif]")
+		os.Exit(exitFailure)
+	}
+	if _, err := parseOutputFormat(opts.format); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitFailure)
+	}
+	if !validator.ValidLanguage(opts.language) {
+		fmt.Fprintf(os.Stderr, "unsupported language %q (use %s)\n", opts.language, strings.Join(validator.Languages(), ", "))
+		os.Exit(exitFailure)
+	}
+
+	files, err := collectValidateFiles(opts.paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitFailure)
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(os.Stderr, "no files matched %s\n", strings.Join(opts.paths, " "))
+		os.Exit(exitFailure)
+	}
+
+	catalogPath := resolveCatalogPath(opts.catalogFlag)
+	qs, err := loadCatalog(catalogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitFailure)
+	}
+
+	rules, err := loadRuleConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(exitFailure)
+	}
+
+	pm := parser.NewParserManager(nil)
+	v := validator.NewValidator(qs.Catalog, qs.Index, pm)
+	v.SetRuleConfig(rules)
+	v.SetTransparentComponents(transparentComponents())
+
+	code := executeValidate(os.Stdout, v, files, opts)
+	_ = pm.Close()
+	os.Exit(code)
+}
+
+func parseValidateFlags(args []string) validateOptions {
+	opts := validateOptions{format: "text"}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--catalog":
+			if i+1 < len(args) {
+				i++
+				opts.catalogFlag = args[i]
+			}
+		case "--fix", "--write":
+			opts.autoFix = true
+			opts.write = true
+		case "--diff":
+			opts.autoFix = true
+			opts.diff = true
+		case "--baseline":
+			if i+1 < len(args) {
+				i++
+				opts.baseline = args[i]
+			}
+		case "--baseline-write":
+			if i+1 < len(args) {
+				i++
+				opts.baselineWrite = args[i]
+			}
+		case "--changed-since":
+			if i+1 < len(args) {
+				i++
+				opts.changedSince = args[i]
+			}
+		case "--language":
+			if i+1 < len(args) {
+				i++
+				opts.language = args[i]
+			}
+		case "--json":
+			opts.format = "json"
+		case "--format":
+			if i+1 < len(args) {
+				i++
+				opts.format = args[i]
+			}
+		default:
+			if !strings.HasPrefix(args[i], "--") {
+				opts.paths = append(opts.paths, args[i])
+			}
+		}
+	}
+	return opts
+}
+
+// --- File discovery ---
+
+// validateScanConfig returns the discovery config used for directory and glob
+// arguments: every source extension the validator can parse, with the
+// scanner's default exclusions and without type declaration files.
+func validateScanConfig() scanner.ScanConfig {
+	cfg := scanner.DefaultScanConfig()
+	cfg.Include = nil
+	for _, ext := range validator.SourceLanguages() {
+		cfg.Include = append(cfg.Include, "**/*."+ext)
+	}
+	cfg.Exclude = append(cfg.Exclude, "**/*.d.ts", "**/*.d.mts", "**/*.d.cts")
+	return cfg
+}
+
+// collectValidateFiles expands file, directory, and glob arguments into a
+// sorted, de-duplicated list of files. Explicit file arguments are always
+// included; directories and globs go through scanner.DiscoverFiles.
+func collectValidateFiles(args []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+	add := func(path string) {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		switch {
+		case err == nil && info.IsDir():
+			found, err := scanner.DiscoverFiles(arg, validateScanConfig())
+			if err != nil {
+				return nil, fmt.Errorf("cannot scan %s: %w", arg, err)
+			}
+			for _, f := range found {
+				add(f)
+			}
+
+		case err == nil:
+			add(arg)
+
+		case hasGlobMeta(arg):
+			base, pattern := doublestar.SplitPattern(filepath.ToSlash(arg))
+			cfg := validateScanConfig()
+			cfg.Include = []string{pattern}
+			found, err := scanner.DiscoverFiles(base, cfg)
+			if err != nil {
+				return nil, fmt.Errorf("cannot expand %s: %w", arg, err)
+			}
+			for _, f := range found {
+				add(f)
+			}
+
+		default:
+			return nil, fmt.Errorf("cannot read %s: %w", arg, err)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// hasGlobMeta reports whether the argument contains glob metacharacters.
+func hasGlobMeta(arg string) bool {
+	return strings.ContainsAny(arg, "*?[{")
+}
+
+// displayPath returns path relative to the working directory when possible.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
+
+// --- Validation ---
+
+// validateFiles validates each file concurrently over the validator's shared
+// parser pools. Each file is parsed with the grammar for its extension unless
+// opts.language names one. With changes, only violations on changed lines are
+// fixed. Reports are returned in the same order as files.
+func validateFiles(v *validator.Validator, files []string, opts validateOptions, changes changeSet) []fileReport {
+	reports := make([]fileReport, len(files))
+	root := projectRoot()
+
+	numWorkers := util.GetOptimalPoolSize()
+	if numWorkers > len(files) {
+		numWorkers = len(files)
+	}
+
+	jobs := make(chan int, numWorkers*2)
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				path := files[idx]
+				display := displayPath(path)
+				reports[idx] = fileReport{Path: display, file: projectPath(root, path)}
+				code, err := os.ReadFile(path)
+				if err != nil {
+					reports[idx].Error = fmt.Sprintf("cannot read file: %v", err)
+					continue
+				}
+				reports[idx].source = string(code)
+				vopts := validator.ValidateOptions{
+					AutoFix:  opts.autoFix,
+					Filename: reports[idx].file,
+					Language: opts.language,
+				}
+				if changes != nil {
+					vopts.Fix = func(viol validator.Violation) bool { return changes.covers(path, viol) }
+				}
+				reports[idx].ValidationResult = v.ValidatePageWithOptions(string(code), vopts)
+			}
+		}()
+	}
+
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return reports
+}
+
+// executeValidate validates files, prints the report to w, writes fixes back
+// when requested, and returns the process exit code.
+func executeValidate(w io.Writer, v *validator.Validator, files []string, opts validateOptions) int {
+	var base *baseline
+	if opts.baseline != "" {
+		var err error
+		if base, err = loadBaseline(opts.baseline); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return exitFailure
+		}
+	}
+
+	var changes changeSet
+	if opts.changedSince != "" {
+		var err error
+		if changes, err = gitChanges(".", opts.changedSince); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return exitFailure
+		}
+		files = changes.filter(files)
+		if len(files) == 0 {
+			fmt.Fprintf(os.Stderr, "no matching files changed since %s\n", opts.changedSince)
+		}
+	}
+
+	reports := validateFiles(v, files, opts, changes)
+
+	if opts.baselineWrite != "" {
+		return writeBaseline(w, reports, opts.baselineWrite)
+	}
+
+	var baseResult *baselineResult
+	if base != nil {
+		result := base.apply(reports, projectRoot())
+		baseResult = &result
+	}
+
+	// Keep violations on changed lines; validateFiles already left the
+	// others unfixed. This runs after the baseline, so baseline entries
+	// outside the diff are not mistaken for fixed ones.
+	if changes != nil {
+		for i, r := range reports {
+			if r.ValidationResult == nil {
+				continue
+			}
+			r.Retain(func(viol validator.Violation) bool { return changes.covers(files[i], viol) })
+		}
+	}
+
+	exitCode := exitValid
+	var fixed []int
+	for i, r := range reports {
+		if r.Error != "" {
+			exitCode = exitFailure
+			continue
+		}
+		if !r.Valid && exitCode == exitValid {
+			exitCode = exitViolations
+		}
+		if len(r.Fixes) > 0 {
+			fixed = append(fixed, i)
+		}
+	}
+
+	if opts.write && len(fixed) > 0 {
+		paths := make([]string, len(fixed))
+		contents := make([]string, len(fixed))
+		for j, i := range fixed {
+			paths[j] = files[i]
+			contents[j] = reports[i].FixedCode
+		}
+		if err := writeFilesAtomic(paths, contents); err != nil {
+			for _, i := range fixed {
+				reports[i].Error = fmt.Sprintf("fixes not written: %v", err)
+			}
+			exitCode = exitFailure
+		}
+	}
+
+	if opts.diff {
+		for _, i := range fixed {
+			name := filepath.ToSlash(reports[i].Path)
+			reports[i].FixDiff = textdiff.Unified(name, name, reports[i].source, reports[i].FixedCode)
+			reports[i].FixedCode = "" // the diff replaces the full fixed file
+		}
+	}
+
+	var err error
+	switch {
+	case opts.format == "json":
+		err = writeValidateJSON(w, reports, singleFileArg(opts.paths), baseResult)
+	case opts.format == "sarif":
+		err = writeValidateSARIF(w, reports)
+	case opts.diff:
+		printValidateDiffs(w, reports)
+	default:
+		printValidateHuman(w, reports, opts.write)
+		if baseResult != nil {
+			printBaselineResult(w, opts.baseline, *baseResult)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode result: %v\n", err)
+		return exitFailure
+	}
+	return exitCode
+}
+
+// writeBaseline records the violations in reports to path and returns the
+// exit code: files that could not be read are reported and fail the run.
+func writeBaseline(w io.Writer, reports []fileReport, path string) int {
+	exitCode := exitValid
+	for _, r := range reports {
+		if r.Error != "" {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", r.Path, r.Error)
+			exitCode = exitFailure
+		}
+	}
+
+	b := newBaseline(reports)
+	if err := b.write(path); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot write baseline: %v\n", err)
+		return exitFailure
+	}
+	count := 0
+	for _, e := range b.Entries {
+		count += e.Count
+	}
+	fmt.Fprintf(w, "Wrote %d violation(s) in %d file(s) to baseline %s\n", count, len(reports), path)
+	return exitCode
+}
+
+// singleFileArg reports whether the command was given exactly one regular
+// file, in which case JSON output keeps the single-result shape.
+func singleFileArg(paths []string) bool {
+	if len(paths) != 1 {
+		return false
+	}
+	info, err := os.Stat(paths[0])
+	return err == nil && !info.IsDir()
+}
+
+// --- Output ---
+
+func writeValidateJSON(w io.Writer, reports []fileReport, single bool, base *baselineResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if single && len(reports) == 1 && reports[0].ValidationResult != nil {
+		return enc.Encode(reports[0].ValidationResult)
+	}
+	return enc.Encode(validateReport{
+		Valid:    reportsValid(reports),
+		Files:    reports,
+		Summary:  aggregateSummary(reports),
+		Baseline: base,
+	})
+}
+
+func printValidateHuman(w io.Writer, reports []fileReport, wrote bool) {
+	for _, r := range reports {
+		if r.Error != "" && r.ValidationResult == nil {
+			fmt.Fprintf(w, "! %s — %s\n", r.Path, r.Error)
+			continue
+		}
+
+		if r.Valid {
+			fmt.Fprintf(w, "✓ %s — no violations\n", r.Path)
+		} else {
+			fmt.Fprintf(w, "✗ %s — %d violation(s)\n", r.Path, len(r.Violations))
+			for _, viol := range r.Violations {
+				sev := strings.ToUpper(viol.Severity[:1]) + viol.Severity[1:]
+				fmt.Fprintf(w, "  [%s] line %d:%d  %s  (%s)\n", sev, viol.Line, viol.Column, viol.Message, viol.Rule)
+				if viol.Suggestion != "" {
+					fmt.Fprintf(w, "         → %s\n", viol.Suggestion)
+				}
+			}
+		}
+
+		if wrote && len(r.Fixes) > 0 && r.Error == "" {
+			fmt.Fprintf(w, "  %d fix(es) applied — wrote %s\n", len(r.Fixes), r.Path)
+		}
+		if r.Error != "" {
+			fmt.Fprintf(w, "  ! %s\n", r.Error)
+		}
+	}
+
+	if len(reports) > 1 {
+		fmt.Fprintf(w, "\n%s\n", aggregateSummary(reports))
+	}
+}
+
+// printValidateDiffs prints only the unified diffs of fixed files, so the
+// output can be piped to git apply or patch. Read errors go to stderr.
+func printValidateDiffs(w io.Writer, reports []fileReport) {
+	for _, r := range reports {
+		if r.Error != "" {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", r.Path, r.Error)
+		}
+		if r.ValidationResult != nil {
+			fmt.Fprint(w, r.FixDiff)
+		}
+	}
+}
+
+// reportsValid reports whether every file validated without errors.
+func reportsValid(reports []fileReport) bool {
+	for _, r := range reports {
+		if r.Error != "" || r.ValidationResult == nil || !r.Valid {
+			return false
+		}
+	}
+	return true
+}
+
+// aggregateSummary creates a one-line summary across all file reports.
+func aggregateSummary(reports []fileReport) string {
+	var failed, withViolations int
+	counts := make(map[string]int)
+	for _, r := range reports {
+		if r.ValidationResult == nil {
+			failed++
+			continue
+		}
+		if len(r.Violations) > 0 {
+			withViolations++
+		}
+		for _, viol := range r.Violations {
+			counts[viol.Severity]++
+		}
+	}
+
+	parts := []string{fmt.Sprintf("%d file(s) checked", len(reports))}
+	if withViolations > 0 {
+		parts = append(parts, fmt.Sprintf("%d with violations", withViolations))
+	}
+	for _, sev := range []string{"error", "warning", "info"} {
+		if counts[sev] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s(s)", counts[sev], sev))
+		}
+	}
+	if failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d unreadable", failed))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// --- Writing fixes ---
+
+// renameFile is os.Rename, replaceable in tests.
+var renameFile = os.Rename
+
+// writeFilesAtomic replaces each path with its new contents. Every file is
+// first written to a temporary file in the same directory; only once all of
+// them are staged are they renamed into place. Each original is moved aside
+// first and put back if a later rename fails, so a failure part-way leaves
+// the originals untouched.
+func writeFilesAtomic(paths, contents []string) error {
+	temps := make([]string, 0, len(paths))
+	removeTemps := func() {
+		for _, tmp := range temps {
+			_ = os.Remove(tmp)
+		}
+	}
+
+	for i, path := range paths {
+		tmp, err := stageFile(path, contents[i])
+		if err != nil {
+			removeTemps()
+			return fmt.Errorf("cannot write %s: %w", displayPath(path), err)
+		}
+		temps = append(temps, tmp)
+	}
+
+	var backups []string // backups[i] holds the original of paths[i]
+	restore := func() {
+		for i, backup := range backups {
+			_ = renameFile(backup, paths[i])
+		}
+		removeTemps()
+	}
+	for i, tmp := range temps {
+		backup := tmp + ".orig"
+		if err := renameFile(paths[i], backup); err != nil {
+			restore()
+			return fmt.Errorf("cannot replace %s: %w", displayPath(paths[i]), err)
+		}
+		backups = append(backups, backup)
+		if err := renameFile(tmp, paths[i]); err != nil {
+			restore()
+			return fmt.Errorf("cannot replace %s: %w", displayPath(paths[i]), err)
+		}
+	}
+	for _, backup := range backups {
+		_ = os.Remove(backup)
+	}
+	return nil
+}
+
+// stageFile writes contents to a new temporary file next to path, with the
+// same permissions as path, and returns the temporary file's name.
+func stageFile(path, contents string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".uispec-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.WriteString(contents)
+	if err == nil {
+		err = f.Chmod(info.Mode().Perm())
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnana997/uispec/pkg/validator"
+)
+
+// writeTree creates files (with empty content) under root.
+func writeTree(t *testing.T, root string, files ...string) {
+	t.Helper()
+	for _, f := range files {
+		path := filepath.Join(root, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(""), 0644))
+	}
+}
+
+func TestParseValidateFlags(t *testing.T) {
+	opts := parseValidateFlags([]string{"src/a.tsx", "--catalog", "c.json", "src/pages", "--fix", "--json"})
+	assert.Equal(t, []string{"src/a.tsx", "src/pages"}, opts.paths)
+	assert.Equal(t, "c.json", opts.catalogFlag)
+	assert.True(t, opts.autoFix)
+	assert.True(t, opts.write)
+	assert.Equal(t, "json", opts.format)
+
+	opts = parseValidateFlags([]string{"src", "--diff"})
+	assert.True(t, opts.autoFix)
+	assert.True(t, opts.diff)
+	assert.False(t, opts.write)
+
+	opts = parseValidateFlags([]string{"src/App.js", "--language", "jsx"})
+	assert.Equal(t, "jsx", opts.language)
+
+	opts = parseValidateFlags([]string{"src", "--format", "sarif"})
+	assert.Equal(t, "sarif", opts.format)
+
+	opts = parseValidateFlags([]string{"src", "--baseline", "b.json", "--baseline-write", "new.json"})
+	assert.Equal(t, "b.json", opts.baseline)
+	assert.Equal(t, "new.json", opts.baselineWrite)
+
+	opts = parseValidateFlags([]string{"--changed-since", "origin/main"})
+	assert.Equal(t, "origin/main", opts.changedSince)
+	assert.Empty(t, opts.paths)
+
+	opts = parseValidateFlags([]string{"src"})
+	assert.Equal(t, "text", opts.format)
+}
+
+func TestCollectValidateFiles_Directory(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root,
+		"src/page.tsx",
+		"src/nested/card.jsx",
+		"src/util.ts",
+		"src/menu.mjs",
+		"src/types.d.ts",
+		"src/notes.txt",
+		"node_modules/lib/index.tsx",
+	)
+
+	files, err := collectValidateFiles([]string{root})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "src/menu.mjs"),
+		filepath.Join(root, "src/nested/card.jsx"),
+		filepath.Join(root, "src/page.tsx"),
+		filepath.Join(root, "src/util.ts"),
+	}, files)
+}
+
+func TestCollectValidateFiles_Glob(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root,
+		"src/a.tsx",
+		"src/deep/b.tsx",
+		"src/c.jsx",
+	)
+
+	files, err := collectValidateFiles([]string{filepath.Join(root, "src/**/*.tsx")})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "src/a.tsx"),
+		filepath.Join(root, "src/deep/b.tsx"),
+	}, files)
+}
+
+func TestCollectValidateFiles_MarkdownGlob(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root,
+		"docs/button.md",
+		"docs/guides/card.mdx",
+		"docs/notes.txt",
+	)
+
+	files, err := collectValidateFiles([]string{filepath.Join(root, "docs/**/*.md{,x}")})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "docs/button.md"),
+		filepath.Join(root, "docs/guides/card.mdx"),
+	}, files)
+}
+
+func TestCollectValidateFiles_Deduplicates(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, "src/a.tsx")
+
+	file := filepath.Join(root, "src/a.tsx")
+	files, err := collectValidateFiles([]string{file, root, filepath.Join(root, "**/*.tsx")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{file}, files)
+}
+
+func TestCollectValidateFiles_Missing(t *testing.T) {
+	_, err := collectValidateFiles([]string{filepath.Join(t.TempDir(), "missing.tsx")})
+	assert.Error(t, err)
+}
+
+func TestAggregateSummary(t *testing.T) {
+	reports := []fileReport{
+		{Path: "a.tsx", ValidationResult: &validator.ValidationResult{Valid: true}},
+		{Path: "b.tsx", ValidationResult: &validator.ValidationResult{
+			Violations: []validator.Violation{
+				{Rule: "missing-import", Severity: "error"},
+				{Rule: "unknown-prop", Severity: "info"},
+			},
+		}},
+		{Path: "c.tsx", Error: "cannot read file"},
+	}
+
+	assert.Equal(t, "3 file(s) checked, 1 with violations, 1 error(s), 1 info(s), 1 unreadable", aggregateSummary(reports))
+	assert.False(t, reportsValid(reports))
+	assert.True(t, reportsValid(reports[:1]))
+}
+
+func TestWriteFilesAtomic(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a.tsx")
+	b := filepath.Join(root, "b.tsx")
+	require.NoError(t, os.WriteFile(a, []byte("old a"), 0600))
+	require.NoError(t, os.WriteFile(b, []byte("old b"), 0644))
+
+	require.NoError(t, writeFilesAtomic([]string{a, b}, []string{"new a", "new b"}))
+
+	got, err := os.ReadFile(a)
+	require.NoError(t, err)
+	assert.Equal(t, "new a", string(got))
+	got, err = os.ReadFile(b)
+	require.NoError(t, err)
+	assert.Equal(t, "new b", string(got))
+
+	info, err := os.Stat(a)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary files left behind")
+}
+
+func TestWriteFilesAtomic_StagingFailureWritesNothing(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a.tsx")
+	require.NoError(t, os.WriteFile(a, []byte("old a"), 0644))
+	missing := filepath.Join(root, "gone", "b.tsx")
+
+	err := writeFilesAtomic([]string{a, missing}, []string{"new a", "new b"})
+	require.Error(t, err)
+
+	got, err := os.ReadFile(a)
+	require.NoError(t, err)
+	assert.Equal(t, "old a", string(got))
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files left behind")
+}
+
+func TestWriteFilesAtomic_RenameFailureRestoresOriginals(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a.tsx")
+	b := filepath.Join(root, "b.tsx")
+	require.NoError(t, os.WriteFile(a, []byte("old a"), 0644))
+	require.NoError(t, os.WriteFile(b, []byte("old b"), 0644))
+
+	// Fail when the second replacement is moved into place.
+	renames := 0
+	renameFile = func(from, to string) error {
+		if renames++; renames == 4 {
+			return errors.New("disk full")
+		}
+		return os.Rename(from, to)
+	}
+	t.Cleanup(func() { renameFile = os.Rename })
+
+	err := writeFilesAtomic([]string{a, b}, []string{"new a", "new b"})
+	require.ErrorContains(t, err, "disk full")
+
+	for path, want := range map[string]string{a: "old a", b: "old b"} {
+		got, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(got))
+	}
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary or backup files left behind")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 )
 
 // Catalog holds the full design system specification.
@@ -36,6 +37,13 @@ type CatalogIndex struct {
 
 	// ComponentsByCategory maps category name -> []*Component.
 	ComponentsByCategory map[string][]*Component
+
+	// SubComponentByAlias maps a dotted alias (e.g. "Dialog.Trigger") -> sub-component name.
+	SubComponentByAlias map[string]string
+
+	// ComponentByHTML maps an intrinsic element name (e.g. "button") -> the
+	// *Component that replaces it.
+	ComponentByHTML map[string]*Component
 }
 
 // validSeverities defines the allowed severity values.
@@ -45,8 +53,17 @@ var validSeverities = map[string]bool{
 	"info":    true,
 }
 
+// validChildrenPolicies defines the allowed children policy values.
+var validChildrenPolicies = map[string]bool{
+	"":             true,
+	ChildrenAny:    true,
+	ChildrenNone:   true,
+	ChildrenNoText: true,
+}
+
 // Validate checks the catalog for internal consistency.
 // Returns a slice of validation errors (empty slice if valid).
+// Examples are checked separately, by ValidateExamples.
 func (c *Catalog) Validate() []error {
 	var errs []error
 
@@ -62,6 +79,8 @@ func (c *Catalog) Validate() []error {
 	componentNames := make(map[string]bool, len(c.Components))
 	allSubComponentNames := make(map[string]bool)
 	categoryNames := make(map[string]bool, len(c.Categories))
+	htmlReplacements := make(map[string]string) // element → component
+	subAliases := make(map[string]string)       // alias → sub-component
 
 	// Validate categories.
 	for i, cat := range c.Categories {
@@ -99,6 +118,23 @@ func (c *Catalog) Validate() []error {
 			errs = append(errs, fmt.Errorf("component %q: references unknown category %q", comp.Name, comp.Category))
 		}
 
+		// Validate HTML replacements.
+		for _, tag := range comp.ReplacesHTML {
+			if tag == "" || strings.ToLower(tag) != tag || strings.ContainsAny(tag, ".:<> ") {
+				errs = append(errs, fmt.Errorf("component %q: replaces_html entry %q must be a lowercase element name", comp.Name, tag))
+				continue
+			}
+			if other, dup := htmlReplacements[tag]; dup {
+				errs = append(errs, fmt.Errorf("component %q: replaces_html element %q is already replaced by %q", comp.Name, tag, other))
+				continue
+			}
+			htmlReplacements[tag] = comp.Name
+		}
+
+		if !validChildrenPolicies[comp.Children] {
+			errs = append(errs, fmt.Errorf("component %q: invalid children policy %q (must be any/none/no-text)", comp.Name, comp.Children))
+		}
+
 		// Validate props.
 		for j, prop := range comp.Props {
 			if prop.Name == "" {
@@ -108,6 +144,8 @@ func (c *Catalog) Validate() []error {
 				errs = append(errs, fmt.Errorf("component %q props[%d]: type is required", comp.Name, j))
 			}
 		}
+		errs = append(errs, propReplacementErrors(fmt.Sprintf("component %q", comp.Name), comp.Props)...)
+		errs = append(errs, propConstraintErrors(fmt.Sprintf("component %q", comp.Name), comp.Props)...)
 
 		// Validate sub-components.
 		for j, sub := range comp.SubComponents {
@@ -125,6 +163,21 @@ func (c *Catalog) Validate() []error {
 			}
 			allSubComponentNames[sub.Name] = true
 
+			// Validate dotted alias.
+			if sub.Alias != "" {
+				if !isDottedName(sub.Alias) {
+					errs = append(errs, fmt.Errorf("component %q sub-component %q: alias %q must be a dotted name like \"Dialog.Trigger\"", comp.Name, sub.Name, sub.Alias))
+				} else if other, dup := subAliases[sub.Alias]; dup {
+					errs = append(errs, fmt.Errorf("component %q sub-component %q: alias %q is already used by %q", comp.Name, sub.Name, sub.Alias, other))
+				} else {
+					subAliases[sub.Alias] = sub.Name
+				}
+			}
+
+			if !validChildrenPolicies[sub.Children] {
+				errs = append(errs, fmt.Errorf("component %q sub-component %q: invalid children policy %q (must be any/none/no-text)", comp.Name, sub.Name, sub.Children))
+			}
+
 			// Validate sub-component props.
 			for k, prop := range sub.Props {
 				if prop.Name == "" {
@@ -134,6 +187,8 @@ func (c *Catalog) Validate() []error {
 					errs = append(errs, fmt.Errorf("component %q sub-component %q props[%d]: type is required", comp.Name, sub.Name, k))
 				}
 			}
+			errs = append(errs, propReplacementErrors(fmt.Sprintf("component %q sub-component %q", comp.Name, sub.Name), sub.Props)...)
+			errs = append(errs, propConstraintErrors(fmt.Sprintf("component %q sub-component %q", comp.Name, sub.Name), sub.Props)...)
 		}
 
 		// Validate component-level guidelines.
@@ -151,6 +206,40 @@ func (c *Catalog) Validate() []error {
 		}
 	}
 
+	// Cross-reference: composition rules must name defined components or
+	// sub-components.
+	defined := func(name string) bool { return componentNames[name] || allSubComponentNames[name] }
+	for _, comp := range c.Components {
+		if comp.ReplacedBy != "" && (comp.ReplacedBy == comp.Name || !componentNames[comp.ReplacedBy]) {
+			errs = append(errs, fmt.Errorf("component %q: replaced_by references unknown component %q", comp.Name, comp.ReplacedBy))
+		}
+		for _, child := range comp.AllowedChildren {
+			if !defined(child) {
+				errs = append(errs, fmt.Errorf("component %q: allowed_children references unknown component %q", comp.Name, child))
+			}
+		}
+		for _, sub := range comp.SubComponents {
+			for _, child := range sub.AllowedChildren {
+				if !defined(child) {
+					errs = append(errs, fmt.Errorf("sub-component %q: allowed_children references unknown component %q", sub.Name, child))
+				}
+			}
+			for _, parent := range sub.AllowedParents {
+				if !defined(parent) {
+					errs = append(errs, fmt.Errorf("sub-component %q: allowed_parents references unknown component %q", sub.Name, parent))
+				}
+			}
+			for _, ancestor := range sub.AllowedAncestors {
+				if !defined(ancestor) {
+					errs = append(errs, fmt.Errorf("sub-component %q: allowed_ancestors references unknown component %q", sub.Name, ancestor))
+				}
+			}
+			if sub.RequiredAncestor != "" && !defined(sub.RequiredAncestor) {
+				errs = append(errs, fmt.Errorf("sub-component %q: required_ancestor references unknown component %q", sub.Name, sub.RequiredAncestor))
+			}
+		}
+	}
+
 	// Cross-reference: each component listed in a category must exist.
 	for _, cat := range c.Categories {
 		for _, compName := range cat.Components {
@@ -163,6 +252,28 @@ func (c *Catalog) Validate() []error {
 	return errs
 }
 
+// propReplacementErrors checks that each prop's replaced_by names another
+// prop of the same owner.
+func propReplacementErrors(owner string, props []Prop) []error {
+	var errs []error
+	for _, prop := range props {
+		if prop.ReplacedBy == "" {
+			continue
+		}
+		found := false
+		for _, other := range props {
+			if other.Name == prop.ReplacedBy && other.Name != prop.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("%s prop %q: replaced_by references unknown prop %q", owner, prop.Name, prop.ReplacedBy))
+		}
+	}
+	return errs
+}
+
 // BuildIndex creates lookup maps for fast access.
 // Should be called after Validate() passes.
 func (c *Catalog) BuildIndex() *CatalogIndex {
@@ -172,6 +283,8 @@ func (c *Catalog) BuildIndex() *CatalogIndex {
 		SubComponentDef:      make(map[string]*SubComponent),
 		CategoryByName:       make(map[string]*Category, len(c.Categories)),
 		ComponentsByCategory: make(map[string][]*Component),
+		SubComponentByAlias:  make(map[string]string),
+		ComponentByHTML:      make(map[string]*Component),
 	}
 
 	for i := range c.Categories {
@@ -183,10 +296,17 @@ func (c *Catalog) BuildIndex() *CatalogIndex {
 		idx.ComponentByName[comp.Name] = comp
 		idx.ComponentsByCategory[comp.Category] = append(idx.ComponentsByCategory[comp.Category], comp)
 
+		for _, tag := range comp.ReplacesHTML {
+			idx.ComponentByHTML[tag] = comp
+		}
+
 		for j := range comp.SubComponents {
 			sub := &comp.SubComponents[j]
 			idx.SubComponentByName[sub.Name] = comp
 			idx.SubComponentDef[sub.Name] = sub
+			if sub.Alias != "" {
+				idx.SubComponentByAlias[sub.Alias] = sub.Name
+			}
 		}
 	}
 
@@ -216,3 +336,18 @@ func LoadFromBytes(data []byte) (*Catalog, *CatalogIndex, error) {
 	index := catalog.BuildIndex()
 	return &catalog, index, nil
 }
+
+// isDottedName reports whether name is two or more non-empty identifiers
+// joined by dots, e.g. "Dialog.Trigger".
+func isDottedName(name string) bool {
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" || strings.ContainsAny(part, " <>/:-") {
+			return false
+		}
+	}
+	return true
+}
@@ -271,6 +271,79 @@ func TestValidate_DuplicateCategoryName(t *testing.T) {
 	assert.Contains(t, errs[0].Error(), "duplicate category name")
 }
 
+func TestValidate_ReplacesHTMLNotLowercase(t *testing.T) {
+	c := minimalValidCatalog()
+	c.Components[0].ReplacesHTML = []string{"Button"}
+	errs := c.Validate()
+	require.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "must be a lowercase element name")
+}
+
+func TestValidate_ReplacesHTMLDuplicate(t *testing.T) {
+	c := minimalValidCatalog()
+	c.Components[0].ReplacesHTML = []string{"button"}
+	c.Categories[0].Components = append(c.Categories[0].Components, "IconButton")
+	c.Components = append(c.Components, Component{
+		Name:          "IconButton",
+		Category:      "actions",
+		ImportPath:    "@/components/ui/icon-button",
+		ImportedNames: []string{"IconButton"},
+		ReplacesHTML:  []string{"button"},
+	})
+	errs := c.Validate()
+	require.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), `already replaced by "Button"`)
+}
+
+func TestValidate_SubComponentAlias(t *testing.T) {
+	c := compoundCatalog()
+	c.Components[0].SubComponents[0].Alias = "Dialog.Trigger"
+	c.Components[0].SubComponents[1].Alias = "DialogContent"
+	c.Components[0].SubComponents[2].Alias = "Dialog.Trigger"
+	errs := c.Validate()
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "must be a dotted name")
+	assert.Contains(t, errs[1].Error(), `already used by "DialogTrigger"`)
+}
+
+func TestValidate_InvalidChildrenPolicy(t *testing.T) {
+	c := compoundCatalog()
+	c.Components[0].Children = "text-only"
+	c.Components[0].SubComponents[0].Children = ChildrenNoText
+	errs := c.Validate()
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), `invalid children policy "text-only"`)
+}
+
+func TestValidate_CompositionReferences(t *testing.T) {
+	c := compoundCatalog()
+	c.Components[0].AllowedChildren = []string{"DialogTrigger", "Popover"}
+	c.Components[0].SubComponents[1].AllowedChildren = []string{"DialogTitle"}
+	c.Components[0].SubComponents[2].AllowedParents = []string{"DialogBody"}
+	c.Components[0].SubComponents[2].AllowedAncestors = []string{"Dialog", "Sheet"}
+	c.Components[0].SubComponents[2].RequiredAncestor = "Portal"
+	errs := c.Validate()
+	require.Len(t, errs, 4)
+	assert.Contains(t, errs[0].Error(), `allowed_children references unknown component "Popover"`)
+	assert.Contains(t, errs[1].Error(), `allowed_parents references unknown component "DialogBody"`)
+	assert.Contains(t, errs[2].Error(), `allowed_ancestors references unknown component "Sheet"`)
+	assert.Contains(t, errs[3].Error(), `required_ancestor references unknown component "Portal"`)
+}
+
+func TestValidate_ReplacedBy(t *testing.T) {
+	c := minimalValidCatalog()
+	c.Components[0].ReplacedBy = "IconButton"
+	c.Components[0].Props = []Prop{
+		{Name: "variant", Type: "string"},
+		{Name: "kind", Type: "string", Deprecated: true, ReplacedBy: "variant"},
+		{Name: "color", Type: "string", Deprecated: true, ReplacedBy: "tone"},
+	}
+	errs := c.Validate()
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), `prop "color": replaced_by references unknown prop "tone"`)
+	assert.Contains(t, errs[1].Error(), `replaced_by references unknown component "IconButton"`)
+}
+
 // --- BuildIndex() tests ---
 
 func TestBuildIndex_ComponentByName(t *testing.T) {
@@ -324,6 +397,26 @@ func TestBuildIndex_ComponentsByCategory(t *testing.T) {
 	assert.Equal(t, "Dialog", comps[0].Name)
 }
 
+func TestBuildIndex_ComponentByHTML(t *testing.T) {
+	c := minimalValidCatalog()
+	c.Components[0].ReplacesHTML = []string{"button"}
+	idx := c.BuildIndex()
+
+	comp, ok := idx.ComponentByHTML["button"]
+	require.True(t, ok)
+	assert.Equal(t, "Button", comp.Name)
+	assert.NotContains(t, idx.ComponentByHTML, "input")
+}
+
+func TestBuildIndex_SubComponentByAlias(t *testing.T) {
+	c := compoundCatalog()
+	c.Components[0].SubComponents[0].Alias = "Dialog.Trigger"
+	idx := c.BuildIndex()
+
+	assert.Equal(t, "DialogTrigger", idx.SubComponentByAlias["Dialog.Trigger"])
+	assert.Len(t, idx.SubComponentByAlias, 1)
+}
+
 // --- LoadFromFile() tests ---
 
 func TestLoadFromFile_ValidCatalog(t *testing.T) {
@@ -415,6 +508,10 @@ func TestLoadFromFile_ShadcnCatalog(t *testing.T) {
 	require.True(t, ok)
 	assert.Contains(t, dialogContentDef.MustContain, "DialogTitle")
 
+	// Verify raw HTML replacements.
+	assert.Equal(t, "Button", idx.ComponentByHTML["button"].Name)
+	assert.Equal(t, "Input", idx.ComponentByHTML["input"].Name)
+
 	// Verify tokens (expanded to include foreground pairs, chart, sidebar).
 	assert.GreaterOrEqual(t, len(cat.Tokens), 30, "should have at least 30 design tokens")
 
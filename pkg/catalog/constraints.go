@@ -0,0 +1,147 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// validChildrenShapes defines the allowed PropCondition.Children values.
+var validChildrenShapes = map[string]bool{
+	ChildrenEmpty:         true,
+	ChildrenSingleElement: true,
+}
+
+// UnmarshalJSON accepts a condition object or a bare prop name.
+func (c *PropCondition) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*c = PropCondition{Prop: name}
+		return nil
+	}
+	type plain PropCondition // drops the method to avoid recursion
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*c = PropCondition(p)
+	return nil
+}
+
+// MarshalJSON writes a condition on a prop's presence as a bare name.
+func (c PropCondition) MarshalJSON() ([]byte, error) {
+	if c.Prop != "" && len(c.Values) == 0 && c.Children == "" {
+		return json.Marshal(c.Prop)
+	}
+	type plain PropCondition
+	return json.Marshal(plain(c))
+}
+
+// String describes the condition as a clause, e.g. `type is "multiple"`.
+func (c PropCondition) String() string {
+	switch {
+	case c.Children == ChildrenEmpty:
+		return "it has no children"
+	case c.Children == ChildrenSingleElement:
+		return "it has exactly one element child"
+	case len(c.Values) == 1:
+		return fmt.Sprintf("%s is %q", c.Prop, c.Values[0])
+	case len(c.Values) > 1:
+		return fmt.Sprintf("%s is one of %s", c.Prop, quoteList(c.Values))
+	default:
+		return fmt.Sprintf("%s is set", c.Prop)
+	}
+}
+
+// quoteList formats values as `"a", "b"`.
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// propConstraintErrors checks that each prop's constraints refer to other
+// props of the same owner and to values those props allow.
+func propConstraintErrors(owner string, props []Prop) []error {
+	var errs []error
+
+	byName := make(map[string]*Prop, len(props))
+	for i := range props {
+		byName[props[i].Name] = &props[i]
+	}
+
+	for _, prop := range props {
+		where := fmt.Sprintf("%s prop %q", owner, prop.Name)
+		check := func(field string, c PropCondition) {
+			switch {
+			case (c.Prop == "") == (c.Children == ""):
+				errs = append(errs, fmt.Errorf("%s: %s condition must set exactly one of prop or children", where, field))
+			case c.Children != "":
+				if !validChildrenShapes[c.Children] {
+					errs = append(errs, fmt.Errorf("%s: %s has invalid children %q (must be empty/single-element)", where, field, c.Children))
+				}
+				if len(c.Values) > 0 {
+					errs = append(errs, fmt.Errorf("%s: %s values require a prop", where, field))
+				}
+			case c.Prop == prop.Name:
+				errs = append(errs, fmt.Errorf("%s: %s references the prop itself", where, field))
+			default:
+				other, ok := byName[c.Prop]
+				if !ok {
+					errs = append(errs, fmt.Errorf("%s: %s references unknown prop %q", where, field, c.Prop))
+					return
+				}
+				errs = append(errs, valuesErrors(where, field, other, c.Values)...)
+			}
+		}
+
+		for _, c := range prop.Requires {
+			check("requires", c)
+		}
+		for _, c := range prop.ConflictsWith {
+			check("conflicts_with", c)
+		}
+		for _, c := range prop.RequiredWhen {
+			check("required_when", c)
+		}
+		if prop.Required && len(prop.RequiredWhen) > 0 {
+			errs = append(errs, fmt.Errorf("%s: required_when has no effect on a required prop", where))
+		}
+		for i, cv := range prop.AllowedValuesWhen {
+			field := fmt.Sprintf("allowed_values_when[%d]", i)
+			check(field+".when", cv.When)
+			if len(cv.Values) == 0 {
+				errs = append(errs, fmt.Errorf("%s: %s must list at least one value", where, field))
+			}
+			errs = append(errs, valuesErrors(where, field, &prop, cv.Values)...)
+		}
+	}
+	return errs
+}
+
+// valuesErrors checks that values are non-empty and, when target has
+// allowed_values, among them.
+func valuesErrors(where, field string, target *Prop, values []string) []error {
+	var errs []error
+	for _, v := range values {
+		switch {
+		case v == "":
+			errs = append(errs, fmt.Errorf("%s: %s has an empty value", where, field))
+		case len(target.AllowedValues) > 0 && !contains(target.AllowedValues, v):
+			errs = append(errs, fmt.Errorf("%s: %s value %q is not an allowed value of %q", where, field, v, target.Name))
+		}
+	}
+	return errs
+}
+
+// contains reports whether values contains v.
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
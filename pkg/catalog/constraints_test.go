@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropCondition_JSON(t *testing.T) {
+	var prop Prop
+	data := `{
+		"name": "value", "type": "string",
+		"requires": ["onValueChange", {"children": "single-element"}],
+		"conflicts_with": [{"prop": "type", "values": ["multiple"]}]
+	}`
+	require.NoError(t, json.Unmarshal([]byte(data), &prop))
+
+	assert.Equal(t, []PropCondition{{Prop: "onValueChange"}, {Children: ChildrenSingleElement}}, prop.Requires)
+	assert.Equal(t, []PropCondition{{Prop: "type", Values: []string{"multiple"}}}, prop.ConflictsWith)
+
+	out, err := json.Marshal(prop.Requires)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["onValueChange", {"children": "single-element"}]`, string(out))
+}
+
+func TestPropCondition_String(t *testing.T) {
+	assert.Equal(t, "onClick is set", PropCondition{Prop: "onClick"}.String())
+	assert.Equal(t, `type is "multiple"`, PropCondition{Prop: "type", Values: []string{"multiple"}}.String())
+	assert.Equal(t, `size is one of "sm", "lg"`, PropCondition{Prop: "size", Values: []string{"sm", "lg"}}.String())
+	assert.Equal(t, "it has no children", PropCondition{Children: ChildrenEmpty}.String())
+	assert.Equal(t, "it has exactly one element child", PropCondition{Children: ChildrenSingleElement}.String())
+}
+
+func TestValidate_PropConstraints(t *testing.T) {
+	c := minimalValidCatalog()
+	c.Components[0].Props = []Prop{
+		{Name: "type", Type: "string", AllowedValues: []string{"single", "multiple"}},
+		{Name: "collapsible", Type: "boolean", ConflictsWith: []PropCondition{{Prop: "type", Values: []string{"multiple"}}}},
+		{Name: "value", Type: "string", Requires: []PropCondition{{Prop: "onValueChange"}}},
+		{Name: "onValueChange", Type: "function"},
+		{Name: "asChild", Type: "boolean", Requires: []PropCondition{{Children: ChildrenSingleElement}}},
+		{Name: "label", Type: "string", RequiredWhen: []PropCondition{{Children: ChildrenEmpty}}},
+		{Name: "href", Type: "string", ConflictsWith: []PropCondition{{Prop: "onClick"}}},
+		{Name: "onClick", Type: "function"},
+	}
+	assert.Empty(t, c.Validate())
+
+	c.Components[0].Props = []Prop{
+		{Name: "type", Type: "string", AllowedValues: []string{"single", "multiple"}},
+		{Name: "collapsible", Type: "boolean", ConflictsWith: []PropCondition{{Prop: "type", Values: []string{"many"}}}},
+		{Name: "value", Type: "string", Requires: []PropCondition{{Prop: "onChange"}, {Prop: "value"}}},
+		{Name: "asChild", Type: "boolean", Requires: []PropCondition{{Children: "one"}, {}}},
+		{Name: "label", Type: "string", Required: true, RequiredWhen: []PropCondition{{Children: ChildrenEmpty, Values: []string{"x"}}}},
+		{Name: "size", Type: "string", AllowedValuesWhen: []ConditionalValues{{When: PropCondition{Prop: "type", Values: []string{"single"}}}}},
+	}
+	errs := c.Validate()
+
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		`component "Button" prop "collapsible": conflicts_with value "many" is not an allowed value of "type"`,
+		`component "Button" prop "value": requires references unknown prop "onChange"`,
+		`component "Button" prop "value": requires references the prop itself`,
+		`component "Button" prop "asChild": requires has invalid children "one" (must be empty/single-element)`,
+		`component "Button" prop "asChild": requires condition must set exactly one of prop or children`,
+		`component "Button" prop "label": required_when values require a prop`,
+		`component "Button" prop "label": required_when has no effect on a required prop`,
+		`component "Button" prop "size": allowed_values_when[0] must list at least one value`,
+	}, messages)
+}
@@ -0,0 +1,114 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExampleIssue is a problem found in an example, at a 1-based line of the
+// page built for it by ExamplePage.
+type ExampleIssue struct {
+	Line    int
+	Message string
+}
+
+// ExampleCheck validates the page built for an example and returns its
+// problems; none means the example passes. It is usually backed by the
+// validator, which this package cannot import.
+type ExampleCheck func(page string) []ExampleIssue
+
+// ExamplePage wraps example code in a page that can be validated on its own:
+// the JSX is returned from a component, and every catalog component it uses
+// is imported from its import_path. It also returns the number of lines put
+// before the code. Code with its own imports or exports is used as is.
+func (c *Catalog) ExamplePage(code string) (page string, offset int) {
+	for _, line := range strings.Split(code, "\n") {
+		if strings.HasPrefix(line, "import ") || strings.HasPrefix(line, "export ") {
+			return code, 0
+		}
+	}
+
+	importPaths := make(map[string]string) // imported name → import path
+	for _, comp := range c.Components {
+		for _, name := range comp.ImportedNames {
+			if _, ok := importPaths[name]; !ok {
+				importPaths[name] = comp.ImportPath
+			}
+		}
+	}
+	byPath := make(map[string][]string)
+	for _, name := range tagNames(code) {
+		if path, ok := importPaths[name]; ok {
+			byPath[path] = append(byPath[path], name)
+		}
+	}
+	paths := make([]string, 0, len(byPath))
+	for path := range byPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&b, "import { %s } from %q\n", strings.Join(byPath[path], ", "), path)
+	}
+	b.WriteString("\nexport default function Example() {\n  return (\n    <>\n")
+	offset = strings.Count(b.String(), "\n")
+	b.WriteString(strings.TrimRight(code, "\n"))
+	b.WriteString("\n    </>\n  )\n}\n")
+	return b.String(), offset
+}
+
+// tagNames returns the distinct capitalised tag names in code, in order of
+// first use. A dotted tag such as <Dialog.Trigger> yields its root, Dialog.
+func tagNames(code string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for i := 0; i < len(code)-1; i++ {
+		if code[i] != '<' || code[i+1] < 'A' || code[i+1] > 'Z' {
+			continue
+		}
+		j := i + 1
+		for j < len(code) && isIdentByte(code[j]) {
+			j++
+		}
+		if name := code[i+1 : j]; !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+		i = j - 1
+	}
+	return names
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// ValidateExamples runs check on the page of every component example and
+// returns an error per problem, naming the component, the example and the
+// line of its code. Validate does not call it: checking an example needs the
+// validator, which imports this package, and parsing every example would slow
+// down each catalog load. `uispec catalog check` runs it.
+func (c *Catalog) ValidateExamples(check ExampleCheck) []error {
+	var errs []error
+	for _, comp := range c.Components {
+		for i, ex := range comp.Examples {
+			name := ex.Title
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			page, offset := c.ExamplePage(ex.Code)
+			for _, issue := range check(page) {
+				line := issue.Line - offset
+				if line < 1 || line > strings.Count(strings.TrimRight(ex.Code, "\n"), "\n")+1 {
+					errs = append(errs, fmt.Errorf("component %q example %q: %s", comp.Name, name, issue.Message))
+					continue
+				}
+				errs = append(errs, fmt.Errorf("component %q example %q line %d: %s", comp.Name, name, line, issue.Message))
+			}
+		}
+	}
+	return errs
+}
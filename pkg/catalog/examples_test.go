@@ -0,0 +1,78 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExamplePage(t *testing.T) {
+	c := compoundCatalog()
+	c.Components = append(c.Components, minimalValidCatalog().Components...)
+
+	page, offset := c.ExamplePage("<Dialog>\n  <DialogTrigger asChild>\n    <Button>Open</Button>\n  </DialogTrigger>\n  <Icon />\n</Dialog>\n")
+
+	assert.Equal(t, `import { Button } from "@/components/ui/button"
+import { Dialog, DialogTrigger } from "@/components/ui/dialog"
+
+export default function Example() {
+  return (
+    <>
+<Dialog>
+  <DialogTrigger asChild>
+    <Button>Open</Button>
+  </DialogTrigger>
+  <Icon />
+</Dialog>
+    </>
+  )
+}
+`, page)
+	assert.Equal(t, 6, offset)
+	assert.Equal(t, "<Dialog>", strings.Split(page, "\n")[offset])
+
+	code := "import { Button } from \"@/components/ui/button\"\n\nexport default () => <Button />\n"
+	page, offset = c.ExamplePage(code)
+	assert.Equal(t, code, page, "complete pages are used as is")
+	assert.Zero(t, offset)
+}
+
+func TestTagNames(t *testing.T) {
+	assert.Equal(t, []string{"Dialog", "Button", "Input"},
+		tagNames(`<Dialog.Trigger><Button>a < b</Button><div /><Input/><Button /></Dialog.Trigger>`))
+}
+
+func TestValidateExamples(t *testing.T) {
+	c := minimalValidCatalog()
+	c.Components[0].Examples = []Example{
+		{Title: "Good", Code: `<Button>Go</Button>`},
+		{Title: "Bad", Code: "<Button>Go</Button>\n<Button variant=\"fancy\">Go</Button>"},
+		{Code: `<Buton />`},
+	}
+
+	var pages []string
+	errs := c.ValidateExamples(func(page string) []ExampleIssue {
+		pages = append(pages, page)
+		var issues []ExampleIssue
+		for i, line := range strings.Split(page, "\n") {
+			if strings.Contains(line, "fancy") {
+				issues = append(issues, ExampleIssue{Line: i + 1, Message: "invalid variant"})
+			}
+			if strings.Contains(line, "Buton") {
+				issues = append(issues, ExampleIssue{Line: 1, Message: "missing import"})
+			}
+		}
+		return issues
+	})
+
+	assert.Len(t, pages, 3)
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		`component "Button" example "Bad" line 2: invalid variant`,
+		`component "Button" example "#3": missing import`,
+	}, messages)
+}
@@ -2,30 +2,45 @@ package catalog
 
 // Component represents a top-level UI component in the catalog.
 type Component struct {
-	Name          string         `json:"name"`
-	Description   string         `json:"description"`
-	Category      string         `json:"category"`
-	ImportPath    string         `json:"import_path"`
-	ImportedNames []string       `json:"imported_names"`
-	Props         []Prop         `json:"props,omitempty"`
-	SubComponents []SubComponent `json:"sub_components,omitempty"`
-	Examples      []Example      `json:"examples,omitempty"`
-	Guidelines    []Guideline    `json:"guidelines,omitempty"`
-	Deprecated    bool           `json:"deprecated,omitempty"`
-	DeprecatedMsg string         `json:"deprecated_msg,omitempty"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description"`
+	Category        string         `json:"category"`
+	ImportPath      string         `json:"import_path"`
+	ImportedNames   []string       `json:"imported_names"`
+	ReplacesHTML    []string       `json:"replaces_html,omitempty"` // intrinsic elements this component should be used instead of
+	Props           []Prop         `json:"props,omitempty"`
+	SubComponents   []SubComponent `json:"sub_components,omitempty"`
+	AllowedChildren []string       `json:"allowed_children,omitempty"`
+	Children        string         `json:"children,omitempty"` // children policy: "any" (default), "none", or "no-text"
+	Examples        []Example      `json:"examples,omitempty"`
+	Guidelines      []Guideline    `json:"guidelines,omitempty"`
+	Deprecated      bool           `json:"deprecated,omitempty"`
+	DeprecatedMsg   string         `json:"deprecated_msg,omitempty"`
+	ReplacedBy      string         `json:"replaced_by,omitempty"` // component to use instead when deprecated
 }
 
 // SubComponent represents a nested part of a compound component.
 // For example, DialogTrigger and DialogContent are sub-components of Dialog.
 type SubComponent struct {
-	Name            string   `json:"name"`
-	Description     string   `json:"description"`
-	Props           []Prop   `json:"props,omitempty"`
-	MustContain     []string `json:"must_contain,omitempty"`
-	AllowedChildren []string `json:"allowed_children,omitempty"`
-	AllowedParents  []string `json:"allowed_parents,omitempty"`
+	Name             string   `json:"name"`
+	Alias            string   `json:"alias,omitempty"` // dotted static-member form, e.g. "Dialog.Trigger"
+	Description      string   `json:"description"`
+	Props            []Prop   `json:"props,omitempty"`
+	MustContain      []string `json:"must_contain,omitempty"`
+	AllowedChildren  []string `json:"allowed_children,omitempty"`
+	AllowedParents   []string `json:"allowed_parents,omitempty"`
+	AllowedAncestors []string `json:"allowed_ancestors,omitempty"` // at least one must enclose this sub-component, at any depth
+	RequiredAncestor string   `json:"required_ancestor,omitempty"` // must enclose this sub-component, at any depth
+	Children         string   `json:"children,omitempty"`          // children policy: "any" (default), "none", or "no-text"
 }
 
+// Children policies for Component.Children and SubComponent.Children.
+const (
+	ChildrenAny    = "any"     // anything may be nested (the default)
+	ChildrenNone   = "none"    // no children at all, e.g. Input
+	ChildrenNoText = "no-text" // elements only, no bare text
+)
+
 // Prop represents a component property.
 type Prop struct {
 	Name          string   `json:"name"`
@@ -35,6 +50,37 @@ type Prop struct {
 	Description   string   `json:"description,omitempty"`
 	AllowedValues []string `json:"allowed_values,omitempty"`
 	Deprecated    bool     `json:"deprecated,omitempty"`
+	ReplacedBy    string   `json:"replaced_by,omitempty"` // prop to use instead when deprecated
+
+	// Constraints between props. Conditions name other props of the same
+	// component or the usage's children; see PropCondition.
+	Requires          []PropCondition     `json:"requires,omitempty"`            // must all hold while this prop is set
+	ConflictsWith     []PropCondition     `json:"conflicts_with,omitempty"`      // must not hold while this prop is set
+	RequiredWhen      []PropCondition     `json:"required_when,omitempty"`       // this prop is required while any holds
+	AllowedValuesWhen []ConditionalValues `json:"allowed_values_when,omitempty"` // narrower allowed values under a condition
+}
+
+// PropCondition is a condition on a component usage that a prop constraint
+// refers to. It names either a prop, which holds when the prop is set (to
+// one of Values, if given), or a children shape. In JSON a bare string is
+// shorthand for {"prop": name}.
+type PropCondition struct {
+	Prop     string   `json:"prop,omitempty"`
+	Values   []string `json:"values,omitempty"`
+	Children string   `json:"children,omitempty"` // ChildrenEmpty or ChildrenSingleElement
+}
+
+// Children shapes for PropCondition.Children.
+const (
+	ChildrenEmpty         = "empty"          // no children at all
+	ChildrenSingleElement = "single-element" // exactly one element and nothing else, as asChild needs
+)
+
+// ConditionalValues restricts a prop to Values while When holds, e.g.
+// value="single" only while type="single".
+type ConditionalValues struct {
+	When   PropCondition `json:"when"`
+	Values []string      `json:"values"`
 }
 
 // Example represents a usage example for a component.
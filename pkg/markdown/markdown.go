@@ -0,0 +1,302 @@
+// Package markdown finds the JSX in Markdown and MDX documents so it can be
+// validated like a page: fenced code blocks and, in MDX, the import/export
+// statements and JSX sections written directly in the document.
+package markdown
+
+import (
+	"strings"
+)
+
+// IgnoreMeta in a fence's info string (```tsx uispec-ignore) skips the block,
+// e.g. for examples of what not to do.
+const IgnoreMeta = "uispec-ignore"
+
+// fenceLanguages maps fence info languages to validator language hints.
+// Only grammars that accept JSX are listed.
+var fenceLanguages = map[string]string{
+	"tsx":        "tsx",
+	"jsx":        "jsx",
+	"js":         "js",
+	"javascript": "js",
+}
+
+// mdxLanguage is the grammar MDX's own ESM and JSX are parsed with.
+const mdxLanguage = "jsx"
+
+// Block is source code found in a document. Code is made of pieces of the
+// document, so positions in Code can be mapped back to it.
+type Block struct {
+	// Language is a validator language hint such as "tsx".
+	Language string
+	Code     string
+
+	pieces []piece
+}
+
+// piece records that Code[code:code+n] is the document's [doc:doc+n].
+type piece struct {
+	code, doc, n int
+}
+
+// line is a document line; end excludes the newline, next includes it.
+type line struct {
+	start, end, next int
+	text             string // without the newline or a trailing \r
+}
+
+// Extract returns the fenced tsx, jsx and js blocks of doc in order. With mdx,
+// the document's import/export statements and JSX sections follow as one
+// more block. Inline JSX inside a paragraph is not extracted.
+func Extract(doc string, mdx bool) []Block {
+	lines := splitLines(doc)
+
+	var blocks []Block
+	var page pageBuilder
+	var scan tagScanner
+	for i := 0; i < len(lines); {
+		if f, ok := openingFence(lines[i].text); ok {
+			j := i + 1
+			for j < len(lines) && !f.closedBy(lines[j].text) {
+				j++
+			}
+			if lang, ok := f.language(); ok && j > i+1 {
+				start, end := lines[i+1].start, lines[j-1].next
+				blocks = append(blocks, Block{
+					Language: lang,
+					Code:     doc[start:end],
+					pieces:   []piece{{code: 0, doc: start, n: end - start}},
+				})
+			}
+			i = j + 1
+			continue
+		}
+		if !mdx || blank(lines[i].text) {
+			i++
+			continue
+		}
+
+		// A paragraph runs to the next blank line or fence.
+		j := i + 1
+		for j < len(lines) && !blank(lines[j].text) {
+			if _, ok := openingFence(lines[j].text); ok {
+				break
+			}
+			j++
+		}
+		start, end := lines[i].start, lines[j-1].next
+		switch {
+		case scan.open():
+			// Inside an element, JSX paragraphs continue it and Markdown
+			// ones are its children, which are left out.
+			if isJSX(lines[i].text) {
+				page.add(doc, start, end, "\n")
+				scan.feed(doc[start:end])
+			}
+		case isESM(lines[i].text):
+			page.add(doc, start, end, ";\n")
+		case isJSX(lines[i].text):
+			page.add(doc, start, end, ";\n")
+			scan.feed(doc[start:end])
+		}
+		i = j
+	}
+
+	if page.b.Len() > 0 {
+		blocks = append(blocks, Block{Language: mdxLanguage, Code: page.b.String(), pieces: page.pieces})
+	}
+	return blocks
+}
+
+// DocOffset maps a byte offset in Code to the document. An offset in the
+// text joining two pieces maps to the end of the piece before it.
+func (b Block) DocOffset(offset int) int {
+	for i := len(b.pieces) - 1; i >= 0; i-- {
+		p := b.pieces[i]
+		if offset >= p.code {
+			return p.doc + min(offset-p.code, p.n)
+		}
+	}
+	return 0
+}
+
+// Contiguous reports whether Code[start:end] is copied from a single range
+// of the document, so that an edit to it can be made there.
+func (b Block) Contiguous(start, end int) bool {
+	for _, p := range b.pieces {
+		if start >= p.code && end <= p.code+p.n {
+			return true
+		}
+	}
+	return false
+}
+
+// pageBuilder joins document ranges into a block's code.
+type pageBuilder struct {
+	b      strings.Builder
+	pieces []piece
+}
+
+// add appends doc[start:end], after sep unless it is the first piece.
+func (p *pageBuilder) add(doc string, start, end int, sep string) {
+	if p.b.Len() > 0 {
+		p.b.WriteString(sep)
+	}
+	p.pieces = append(p.pieces, piece{code: p.b.Len(), doc: start, n: end - start})
+	p.b.WriteString(doc[start:end])
+}
+
+// splitLines splits doc into lines, keeping their offsets.
+func splitLines(doc string) []line {
+	var lines []line
+	for start := 0; start < len(doc); {
+		end := strings.IndexByte(doc[start:], '\n')
+		next := start + end + 1
+		if end < 0 {
+			end, next = len(doc)-start, len(doc)
+		}
+		end += start
+		lines = append(lines, line{start: start, end: end, next: next, text: strings.TrimSuffix(doc[start:end], "\r")})
+		start = next
+	}
+	return lines
+}
+
+// blank reports whether a line has only whitespace.
+func blank(text string) bool {
+	return strings.TrimSpace(text) == ""
+}
+
+// isESM reports whether a line starts an MDX import or export statement.
+func isESM(text string) bool {
+	return strings.HasPrefix(text, "import ") || strings.HasPrefix(text, "import{") ||
+		strings.HasPrefix(text, "export ")
+}
+
+// isJSX reports whether a line starts an MDX JSX section: a tag or fragment
+// indented by at most three spaces.
+func isJSX(text string) bool {
+	trimmed := strings.TrimLeft(text, " ")
+	if len(text)-len(trimmed) > 3 || len(trimmed) < 2 || trimmed[0] != '<' {
+		return false
+	}
+	c := trimmed[1]
+	return c == '>' || c == '/' || isLetter(c)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// fence is an opening code fence.
+type fence struct {
+	char byte // '`' or '~'
+	n    int
+	info string
+}
+
+// openingFence parses a CommonMark opening fence: three or more backticks
+// or tildes, indented by at most three spaces.
+func openingFence(text string) (fence, bool) {
+	rest := strings.TrimLeft(text, " ")
+	if len(text)-len(rest) > 3 || rest == "" || (rest[0] != '`' && rest[0] != '~') {
+		return fence{}, false
+	}
+	n := 0
+	for n < len(rest) && rest[n] == rest[0] {
+		n++
+	}
+	info := strings.TrimSpace(rest[n:])
+	if n < 3 || (rest[0] == '`' && strings.Contains(info, "`")) {
+		return fence{}, false
+	}
+	return fence{char: rest[0], n: n, info: info}, true
+}
+
+// closedBy reports whether text closes the fence.
+func (f fence) closedBy(text string) bool {
+	rest := strings.TrimLeft(text, " ")
+	if len(text)-len(rest) > 3 {
+		return false
+	}
+	n := 0
+	for n < len(rest) && rest[n] == f.char {
+		n++
+	}
+	return n >= f.n && blank(rest[n:])
+}
+
+// language returns the validator language hint for the fence, and false if
+// the block is not JSX or is marked with IgnoreMeta.
+func (f fence) language() (string, bool) {
+	fields := strings.Fields(f.info)
+	if len(fields) == 0 {
+		return "", false
+	}
+	for _, field := range fields[1:] {
+		if field == IgnoreMeta {
+			return "", false
+		}
+	}
+	lang, ok := fenceLanguages[strings.ToLower(fields[0])]
+	return lang, ok
+}
+
+// tagScanner tracks how deeply JSX is nested across MDX paragraphs, so an
+// element whose children are Markdown stays open until its closing tag.
+type tagScanner struct {
+	depth   int
+	inTag   bool // between < and >
+	closing bool // the tag being read is a closing tag
+	braces  int  // depth of {expressions}
+	quote   byte // the quote of the string being read, if any
+}
+
+// open reports whether an element or tag is still open.
+func (s *tagScanner) open() bool {
+	return s.depth > 0 || s.inTag || s.braces > 0
+}
+
+// feed advances the scanner over text.
+func (s *tagScanner) feed(text string) {
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case s.quote != 0:
+			if c == s.quote {
+				s.quote = 0
+			}
+		case s.braces > 0:
+			switch c {
+			case '{':
+				s.braces++
+			case '}':
+				s.braces--
+			case '"', '\'', '`':
+				s.quote = c
+			}
+		case c == '{':
+			s.braces++
+		case s.inTag:
+			switch c {
+			case '"', '\'':
+				s.quote = c
+			case '>':
+				s.inTag = false
+				switch {
+				case s.closing:
+					s.depth = max(s.depth-1, 0)
+				case i > 0 && text[i-1] == '/':
+					// self-closing
+				default:
+					s.depth++
+				}
+			}
+		case c == '<' && i+1 < len(text):
+			next := text[i+1]
+			if next == '>' || next == '/' || isLetter(next) {
+				s.inTag = true
+				s.closing = next == '/'
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract_Fences(t *testing.T) {
+	doc := "# Button\n" +
+		"\n" +
+		"```tsx title=\"page.tsx\"\n" +
+		"<Button variant=\"outline\">Go</Button>\n" +
+		"```\n" +
+		"\n" +
+		"~~~~jsx\n" +
+		"<Card />\n" +
+		"```\n" +
+		"~~~~\n" +
+		"```bash\n" +
+		"npm i\n" +
+		"```\n" +
+		"```tsx uispec-ignore\n" +
+		"<Buton />\n" +
+		"```\n" +
+		"  ```javascript\n" +
+		"  <Badge />\n"
+
+	blocks := Extract(doc, false)
+
+	require.Len(t, blocks, 3)
+	assert.Equal(t, "tsx", blocks[0].Language)
+	assert.Equal(t, "<Button variant=\"outline\">Go</Button>\n", blocks[0].Code)
+	assert.Equal(t, "jsx", blocks[1].Language)
+	assert.Equal(t, "<Card />\n```\n", blocks[1].Code, "a shorter or different fence does not close it")
+	assert.Equal(t, "js", blocks[2].Language)
+	assert.Equal(t, "  <Badge />\n", blocks[2].Code, "an unclosed fence runs to the end")
+
+	for _, b := range blocks {
+		start := b.DocOffset(0)
+		assert.Equal(t, b.Code, doc[start:start+len(b.Code)])
+	}
+}
+
+func TestExtract_MDX(t *testing.T) {
+	doc := strings.Join([]string{
+		`import { Button } from "@/components/ui/button"`, // 0
+		`import { Callout } from "@/components/callout"`,
+		``,
+		`# Usage`,
+		``,
+		`Use a <Button> inline.`, // 5: inline JSX is not extracted
+		``,
+		`<Button variant="outline">`,
+		`  Save`,
+		`</Button>`,
+		``,
+		`<Callout`, // 11
+		`  title="Note"`,
+		`>`,
+		``,
+		`Markdown **children**.`, // 15: left out
+		``,
+		`</Callout>`,
+		``,
+		"```tsx",
+		`<Card />`,
+		"```",
+		``,
+	}, "\n")
+
+	blocks := Extract(doc, true)
+
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "<Card />\n", blocks[0].Code)
+
+	mdx := blocks[1]
+	assert.Equal(t, "jsx", mdx.Language)
+	assert.Equal(t, strings.Join([]string{
+		`import { Button } from "@/components/ui/button"`,
+		`import { Callout } from "@/components/callout"`,
+		`;`,
+		`<Button variant="outline">`,
+		`  Save`,
+		`</Button>`,
+		`;`,
+		`<Callout`,
+		`  title="Note"`,
+		`>`,
+		``,
+		`</Callout>`,
+		``,
+	}, "\n"), mdx.Code)
+
+	// Offsets map back to the document, including after a left-out paragraph.
+	closing := strings.Index(mdx.Code, "</Callout>")
+	assert.Equal(t, strings.Index(doc, "</Callout>"), mdx.DocOffset(closing))
+	save := strings.Index(mdx.Code, "Save")
+	assert.Equal(t, strings.Index(doc, "Save"), mdx.DocOffset(save))
+
+	assert.True(t, mdx.Contiguous(save, save+len("Save")))
+	sep := strings.Index(mdx.Code, ";")
+	assert.False(t, mdx.Contiguous(sep-1, sep+1), "spans the text joining two pieces")
+	assert.Equal(t, strings.Index(doc, "\n\n# Usage")+1, mdx.DocOffset(sep), "a separator maps to the end of the piece before it")
+}
+
+func TestExtract_MarkdownIgnoresJSX(t *testing.T) {
+	assert.Empty(t, Extract("import x from \"y\"\n\n<Button />\n", false))
+}
+
+func TestTagScanner(t *testing.T) {
+	tests := []struct {
+		text string
+		open bool
+	}{
+		{`<Button />`, false},
+		{`<Card><CardContent>x</CardContent></Card>`, false},
+		{`<Callout title="a > b">`, true},
+		{`<Callout onClick={() => a > b}>`, true},
+		{`<>`, true},
+		{`<></>`, false},
+		{`<Card`, true},
+		{`</Card>`, false},
+		{`<p>a < b</p>`, false},
+	}
+	for _, tt := range tests {
+		var s tagScanner
+		s.feed(tt.text)
+		assert.Equal(t, tt.open, s.open(), tt.text)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/gnana997/uispec/pkg/validator"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -155,8 +156,23 @@ func (s *Server) handleValidatePage(_ context.Context, req mcp.CallToolRequest)
 		return mcp.NewToolResultError("code parameter is required"), nil
 	}
 
-	autoFix := req.GetBool("auto_fix", false)
-	result := s.validator.ValidatePage(code, autoFix)
+	language := req.GetString("language", "")
+	if !validator.ValidLanguage(language) {
+		return mcp.NewToolResultError(fmt.Sprintf("unsupported language %q", language)), nil
+	}
+
+	fixFormat := req.GetString("fix_format", validator.FixFormatCode)
+	if fixFormat != validator.FixFormatCode && fixFormat != validator.FixFormatDiff {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid fix_format %q: expected %q or %q",
+			fixFormat, validator.FixFormatCode, validator.FixFormatDiff)), nil
+	}
+
+	result := s.validator.ValidatePageWithOptions(code, validator.ValidateOptions{
+		AutoFix:   req.GetBool("auto_fix", false),
+		Filename:  req.GetString("filename", ""),
+		Language:  language,
+		FixFormat: fixFormat,
+	})
 	return mcp.NewToolResultJSON(result)
 }
 
@@ -170,6 +186,14 @@ func (s *Server) handleAnalyzePage(_ context.Context, req mcp.CallToolRequest) (
 		return mcp.NewToolResultError("code parameter is required"), nil
 	}
 
-	analysis := s.validator.AnalyzePage(code)
+	language := req.GetString("language", "")
+	if !validator.ValidLanguage(language) {
+		return mcp.NewToolResultError(fmt.Sprintf("unsupported language %q", language)), nil
+	}
+
+	analysis := s.validator.AnalyzePageWithOptions(code, validator.AnalyzeOptions{
+		Filename: req.GetString("filename", ""),
+		Language: language,
+	})
 	return mcp.NewToolResultJSON(analysis)
 }
@@ -381,6 +381,44 @@ func TestHandleValidatePage_AutoFix(t *testing.T) {
 	assert.Contains(t, fixedCode, `import { Button } from "@/components/ui/button"`)
 }
 
+func TestHandleValidatePage_AutoFixDiff(t *testing.T) {
+	s := testServerWithValidator()
+	code := "export default function Page() { return <Button>Click</Button> }\n"
+	result := callTool(t, s, makeRequest("validate_page", map[string]any{
+		"code":       code,
+		"auto_fix":   true,
+		"filename":   "app/page.tsx",
+		"fix_format": "diff",
+	}))
+	assert.False(t, result.IsError)
+
+	var vr map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resultJSON(t, result)), &vr))
+	assert.NotContains(t, vr, "fixed_code")
+	fixDiff, ok := vr["fix_diff"].(string)
+	require.True(t, ok)
+	assert.Contains(t, fixDiff, "--- a/app/page.tsx\n+++ b/app/page.tsx\n")
+	assert.Contains(t, fixDiff, `+import { Button } from "@/components/ui/button"`)
+}
+
+func TestHandleValidatePage_InvalidFixFormat(t *testing.T) {
+	s := testServerWithValidator()
+	result := callTool(t, s, makeRequest("validate_page", map[string]any{
+		"code":       "<Button />",
+		"fix_format": "patch",
+	}))
+	assert.True(t, result.IsError)
+}
+
+func TestHandleValidatePage_UnsupportedLanguage(t *testing.T) {
+	s := testServerWithValidator()
+	result := callTool(t, s, makeRequest("validate_page", map[string]any{
+		"code":     "<Button />",
+		"language": "vue",
+	}))
+	assert.True(t, result.IsError)
+}
+
 func TestHandleValidatePage_NoValidator(t *testing.T) {
 	s := testServer() // no validator
 	result := callTool(t, s, makeRequest("validate_page", map[string]any{"code": "<Button />"}))
@@ -405,6 +443,52 @@ export default function Page() { return <Button variant="default">Click</Button>
 	assert.Greater(t, len(comps), 0)
 }
 
+func TestHandleAnalyzePage_JavaScript(t *testing.T) {
+	s := testServerWithValidator()
+	code := `
+import { Button } from "@/components/ui/button"
+export default function Page({ label }) { return <Button>{label}</Button> }
+`
+	result := callTool(t, s, makeRequest("analyze_page", map[string]any{
+		"code":     code,
+		"filename": "src/Page.jsx",
+	}))
+	assert.False(t, result.IsError)
+
+	var analysis map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resultJSON(t, result)), &analysis))
+	comps, ok := analysis["components"].([]any)
+	require.True(t, ok)
+	assert.Len(t, comps, 1)
+}
+
+func TestHandleAnalyzePage_Markdown(t *testing.T) {
+	s := testServerWithValidator()
+	doc := "# Save\n\n```tsx\n<Button>Save</Button>\n```\n"
+	result := callTool(t, s, makeRequest("analyze_page", map[string]any{
+		"code":     doc,
+		"language": "md",
+	}))
+	assert.False(t, result.IsError)
+
+	var analysis map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resultJSON(t, result)), &analysis))
+	comps, ok := analysis["components"].([]any)
+	require.True(t, ok)
+	require.Len(t, comps, 1)
+	assert.Equal(t, float64(4), comps[0].(map[string]any)["line"])
+}
+
+func TestLanguageParam_MatchesValidLanguage(t *testing.T) {
+	for _, tool := range []mcp.Tool{validatePageTool(), analyzePageTool()} {
+		enum := tool.InputSchema.Properties["language"].(map[string]any)["enum"].([]string)
+		assert.Equal(t, validator.Languages(), enum, tool.Name)
+		for _, lang := range enum {
+			assert.True(t, validator.ValidLanguage(lang), lang)
+		}
+	}
+}
+
 func TestHandleAnalyzePage_NoValidator(t *testing.T) {
 	s := testServer() // no validator
 	result := callTool(t, s, makeRequest("analyze_page", map[string]any{"code": "<div />"}))
@@ -1,6 +1,9 @@
 package mcp
 
-import "github.com/mark3labs/mcp-go/mcp"
+import (
+	"github.com/gnana997/uispec/pkg/validator"
+	"github.com/mark3labs/mcp-go/mcp"
+)
 
 // listCategoriesTool returns the tool definition for list_categories.
 func listCategoriesTool() mcp.Tool {
@@ -89,6 +92,15 @@ func validatePageTool() mcp.Tool {
 			mcp.Description("Generate and apply deterministic fixes"),
 			mcp.DefaultBool(false),
 		),
+		mcp.WithString("filename",
+			mcp.Description("Path of the page relative to the project root, used to apply per-file rule overrides and to pick the grammar from its extension; for .md and .mdx files the document's JSX code blocks are validated"),
+		),
+		languageParam(),
+		mcp.WithString("fix_format",
+			mcp.Description("How auto-fixed code is returned: \"code\" for the whole fixed page, \"diff\" for a unified diff"),
+			mcp.Enum("code", "diff"),
+			mcp.DefaultString("code"),
+		),
 	)
 }
 
@@ -100,5 +112,17 @@ func analyzePageTool() mcp.Tool {
 			mcp.Required(),
 			mcp.Description("TSX source code to analyze"),
 		),
+		mcp.WithString("filename",
+			mcp.Description("Path of the page, used to pick the grammar from its extension; for .md and .mdx files the document's JSX code blocks are analyzed"),
+		),
+		languageParam(),
+	)
+}
+
+// languageParam is the grammar hint shared by the page tools.
+func languageParam() mcp.ToolOption {
+	return mcp.WithString("language",
+		mcp.Description("Source language of the code, overriding the filename's extension; defaults to tsx. Use md or mdx for a Markdown or MDX document"),
+		mcp.Enum(validator.Languages()...),
 	)
 }
@@ -0,0 +1,195 @@
+// Package textdiff produces line-based unified diffs.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change.
+const contextLines = 3
+
+// Unified returns a unified diff that turns a into b, with "a/" and "b/"
+// prefixed to oldName and newName as git does, or "" if the texts are equal.
+func Unified(oldName, newName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	linesA, linesB := splitLines(a), splitLines(b)
+	ops := diffLines(linesA, linesB)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", oldName, newName)
+	for _, h := range hunks(ops) {
+		writeHunk(&out, ops[h.start:h.end], linesA, linesB)
+	}
+	return out.String()
+}
+
+// splitLines splits text after each newline; the last line has none if the
+// text doesn't end with one.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// op is one step of an edit script. a and b are the line positions in each
+// text before the step.
+type op struct {
+	kind byte // ' ' keep, '-' delete a[a], '+' insert b[b]
+	a, b int
+}
+
+// diffLines returns the shortest edit script turning a into b, using
+// Myers' O(ND) algorithm.
+func diffLines(a, b []string) []op {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+
+	// trace[d] holds v as it was before round d, for backtracking.
+	var trace [][]int
+	found := false
+	for d := 0; d <= n+m && !found; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // down: insertion
+			} else {
+				x = v[offset+k-1] + 1 // right: deletion
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	var ops []op
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		if d == 0 {
+			for x > 0 && y > 0 {
+				x, y = x-1, y-1
+				ops = append(ops, op{kind: ' ', a: x, b: y})
+			}
+			break
+		}
+		prev := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev[offset+k-1] < prev[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			ops = append(ops, op{kind: ' ', a: x, b: y})
+		}
+		if x == prevX {
+			y--
+			ops = append(ops, op{kind: '+', a: x, b: y})
+		} else {
+			x--
+			ops = append(ops, op{kind: '-', a: x, b: y})
+		}
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// hunk is a range of ops shown together.
+type hunk struct {
+	start, end int
+}
+
+// hunks groups changes with up to contextLines unchanged lines around them,
+// merging changes whose context would touch.
+func hunks(ops []op) []hunk {
+	var result []hunk
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+		start := max(i-contextLines, 0)
+		end := i + 1
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			// Run of unchanged lines: end the hunk if it is longer than both contexts.
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*contextLines {
+				end = min(end+contextLines, run)
+				break
+			}
+			end = run
+		}
+		result = append(result, hunk{start: start, end: end})
+		i = end - 1
+	}
+	return result
+}
+
+// writeHunk writes the header and lines of one hunk.
+func writeHunk(out *strings.Builder, ops []op, a, b []string) {
+	var countA, countB int
+	for _, o := range ops {
+		if o.kind != '+' {
+			countA++
+		}
+		if o.kind != '-' {
+			countB++
+		}
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(ops[0].a, countA), hunkRange(ops[0].b, countB))
+
+	for _, o := range ops {
+		var line string
+		if o.kind == '+' {
+			line = b[o.b]
+		} else {
+			line = a[o.a]
+		}
+		out.WriteByte(o.kind)
+		out.WriteString(line)
+		if !strings.HasSuffix(line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the start,count of a hunk header. An empty range starts
+// at the line before it.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
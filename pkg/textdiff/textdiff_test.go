@@ -0,0 +1,69 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnified_Equal(t *testing.T) {
+	assert.Empty(t, Unified("a.tsx", "a.tsx", "x\n", "x\n"))
+}
+
+func TestUnified_SingleChange(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\n"
+	b := "one\ntwo\nthree\nfour\nFIVE\nsix\nseven\neight\n"
+
+	want := `--- a/page.tsx
++++ b/page.tsx
+@@ -2,7 +2,7 @@
+ two
+ three
+ four
+-five
++FIVE
+ six
+ seven
+ eight
+`
+	assert.Equal(t, want, Unified("page.tsx", "page.tsx", a, b))
+}
+
+func TestUnified_Insertion(t *testing.T) {
+	a := "import { A } from \"a\"\n\n<A />\n"
+	b := "import { A } from \"a\"\nimport { B } from \"b\"\n\n<A />\n"
+
+	want := "--- a/p.tsx\n+++ b/p.tsx\n@@ -1,3 +1,4 @@\n" +
+		" import { A } from \"a\"\n" +
+		"+import { B } from \"b\"\n" +
+		" \n" +
+		" <A />\n"
+	assert.Equal(t, want, Unified("p.tsx", "p.tsx", a, b))
+}
+
+func TestUnified_SeparateHunks(t *testing.T) {
+	var a []string
+	for i := 0; i < 20; i++ {
+		a = append(a, fmt.Sprintf("line %d", i))
+	}
+	b := append([]string(nil), a...)
+	b[1], b[18] = "first", "last"
+
+	diff := Unified("f", "f", strings.Join(a, "\n")+"\n", strings.Join(b, "\n")+"\n")
+
+	assert.Equal(t, 2, strings.Count(diff, "@@ -"))
+	assert.Contains(t, diff, "@@ -1,5 +1,5 @@\n line 0\n-line 1\n+first\n")
+	assert.Contains(t, diff, "@@ -16,5 +16,5 @@\n")
+}
+
+func TestUnified_NoTrailingNewline(t *testing.T) {
+	diff := Unified("f", "f", "a\nb", "a\nc")
+
+	assert.Equal(t, "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n", diff)
+}
+
+func TestUnified_EmptyOld(t *testing.T) {
+	assert.Equal(t, "--- a/f\n+++ b/f\n@@ -0,0 +1 @@\n+x\n", Unified("f", "f", "", "x\n"))
+}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/gnana997/uispec/pkg/parser"
+	ts "github.com/tree-sitter/go-tree-sitter"
 )
 
 // PageAnalysis is a compact structural summary of a page's component usage.
@@ -16,19 +16,40 @@ type PageAnalysis struct {
 
 // ComponentSummary describes one component usage in the page.
 type ComponentSummary struct {
-	Name     string   `json:"name"`
-	Line     int      `json:"line"`
-	Props    []string `json:"props"`
-	Children int      `json:"children_count"`
+	Name      string   `json:"name"`
+	LocalName string   `json:"local_name,omitempty"` // tag as written, when aliased or namespaced
+	Line      int      `json:"line"`
+	Props     []string `json:"props"`
+	Children  int      `json:"children_count"`
+}
+
+// AnalyzeOptions controls a single page analysis.
+type AnalyzeOptions struct {
+	// Filename and Language pick the grammar, as in ValidateOptions.
+	Filename string
+	Language string
 }
 
 // AnalyzePage parses TSX code and returns a compact structural summary.
 // This is designed for the analyze_page MCP tool — gives the agent enough
 // information for surgical modifications without reading the full code.
 func (v *Validator) AnalyzePage(code string) *PageAnalysis {
+	return v.AnalyzePageWithOptions(code, AnalyzeOptions{})
+}
+
+// AnalyzePageWithOptions is AnalyzePage for a page in another grammar, such
+// as a .jsx file, or for a Markdown or MDX document.
+func (v *Validator) AnalyzePageWithOptions(code string, opts AnalyzeOptions) *PageAnalysis {
+	if doc, mdx := documentKind(opts.Language, opts.Filename); doc {
+		return v.analyzeDocument(code, mdx)
+	}
 	source := []byte(code)
 
-	tree, err := v.parser.Parse(source, parser.LanguageTypeScript, true)
+	g, err := pageGrammar(opts.Language, opts.Filename)
+	var tree *ts.Tree
+	if err == nil {
+		tree, err = v.parse(source, g)
+	}
 	if err != nil {
 		return &PageAnalysis{
 			LineCount: strings.Count(code, "\n") + 1,
@@ -37,6 +58,8 @@ func (v *Validator) AnalyzePage(code string) *PageAnalysis {
 	defer tree.Close()
 
 	extraction := ExtractJSX(tree, source)
+	v.resolveDefaultImports(extraction)
+	v.resolveSubComponentAliases(extraction)
 
 	// Build component summaries.
 	components := make([]ComponentSummary, 0, len(extraction.Usages))
@@ -63,10 +86,11 @@ func (v *Validator) AnalyzePage(code string) *PageAnalysis {
 
 		key := fmt.Sprintf("%s:%d", usage.ComponentName, usage.Line)
 		components = append(components, ComponentSummary{
-			Name:     usage.ComponentName,
-			Line:     usage.Line,
-			Props:    props,
-			Children: childCount[key],
+			Name:      usage.ComponentName,
+			LocalName: usage.LocalName,
+			Line:      usage.Line,
+			Props:     props,
+			Children:  childCount[key],
 		})
 	}
 
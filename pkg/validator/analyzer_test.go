@@ -58,3 +58,46 @@ func TestAnalyzePage_EmptyCode(t *testing.T) {
 	assert.Empty(t, analysis.Components)
 	assert.Equal(t, 1, analysis.LineCount)
 }
+
+func TestAnalyzePage_JavaScript(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `import { Button } from "@/components/ui/button"
+
+export default function Page({ label }) {
+  return <Button size="lg">{label}</Button>
+}
+`
+	analysis := v.AnalyzePageWithOptions(code, AnalyzeOptions{Language: "jsx"})
+
+	require.Len(t, analysis.Components, 1)
+	assert.Equal(t, "Button", analysis.Components[0].Name)
+	assert.Equal(t, []string{"@/components/ui/button"}, analysis.Imports)
+}
+
+func TestAnalyzePage_Markdown(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	doc := "# Buttons\n" +
+		"\n" +
+		"```tsx\n" +
+		"import { Button } from \"@/components/ui/button\"\n" +
+		"\n" +
+		"export const Save = () => <Button>Save</Button>\n" +
+		"```\n" +
+		"\n" +
+		"```jsx\n" +
+		"import { Button } from \"@/components/ui/button\"\n" +
+		"<Button variant=\"outline\">Cancel</Button>\n" +
+		"```\n"
+
+	analysis := v.AnalyzePageWithOptions(doc, AnalyzeOptions{Filename: "docs/buttons.md"})
+
+	require.Len(t, analysis.Components, 2)
+	assert.Equal(t, 6, analysis.Components[0].Line, "mapped to the document's line")
+	assert.Equal(t, 11, analysis.Components[1].Line)
+	assert.Equal(t, []string{"@/components/ui/button"}, analysis.Imports)
+	assert.Equal(t, 13, analysis.LineCount)
+}
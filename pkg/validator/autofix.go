@@ -3,246 +3,635 @@ package validator
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/gnana997/uispec/pkg/catalog"
 )
 
 // AutoFix represents a deterministic code fix that can be applied without LLM involvement.
+// It replaces the source bytes [StartByte, EndByte), which hold OldText, with
+// NewText; an empty range is an insertion. Lines and columns are 1-based, with
+// columns counted in bytes, and the end position is exclusive. An import
+// added for several violations is linked to the first of them.
 type AutoFix struct {
-	Line    int    `json:"line"`
-	Column  int    `json:"column"`
-	OldText string `json:"old_text"`
-	NewText string `json:"new_text"`
-	Rule    string `json:"rule"`
-	Reason  string `json:"reason"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"end_line"`
+	EndColumn int    `json:"end_column"`
+	StartByte int    `json:"start_byte"`
+	EndByte   int    `json:"end_byte"`
+	OldText   string `json:"old_text"`
+	NewText   string `json:"new_text"`
+	Rule      string `json:"rule"`
+	Reason    string `json:"reason"`
+	Component string `json:"component,omitempty"`
+	Violation int    `json:"violation"` // index of the violation it fixes in ValidationResult.Violations, -1 if not retained
+}
+
+// fixGroup holds the edits that fix a single violation. They are applied
+// together or not at all, e.g. both tags of a renamed element.
+type fixGroup struct {
+	fixes   []AutoFix
+	imports []neededImport
+}
+
+// link records that the group's fixes and imports resolve violation i.
+func (g *fixGroup) link(i int) {
+	for j := range g.fixes {
+		g.fixes[j].Violation = i
+	}
+	for j := range g.imports {
+		g.imports[j].violation = i
+	}
 }
 
 // GenerateFixes creates deterministic fixes for violations and returns the fixed code.
-// Only violations with clear, unambiguous fixes are addressed.
+// Only violations with clear, unambiguous fixes are addressed. Fixes that
+// overlap an earlier one are dropped, so the result never depends on the
+// order of violations.
 func GenerateFixes(code string, violations []Violation, index *catalog.CatalogIndex) ([]AutoFix, string) {
-	var fixes []AutoFix
-	lines := strings.Split(code, "\n")
+	return assembleFixes(code, planFixes(code, violations, index, nil), nil)
+}
 
-	// Track import insertions separately (they go at the top).
-	var missingImports []string
-	lastImportLine := findLastImportLine(lines)
+// generateFixes is GenerateFixes for a page the validator parsed: only the
+// violations fix accepts are fixed (all of them if fix is nil), imports are
+// merged into the parsed import statements, and if the fixed code no longer
+// parses, only the fix groups that keep it parsing are applied.
+func (v *Validator) generateFixes(code string, g grammar, violations []Violation, fix func(Violation) bool, imports []ImportInfo, parsedCleanly bool) ([]AutoFix, string) {
+	groups := planFixes(code, violations, v.index, fix)
+
+	fixes, fixedCode := assembleFixes(code, groups, imports)
+	if len(fixes) == 0 || !parsedCleanly || v.parses(fixedCode, g) {
+		return fixes, fixedCode
+	}
 
-	for _, v := range violations {
+	// Some fix broke the syntax; keep each group only if the code still parses with it.
+	var kept []fixGroup
+	for _, group := range groups {
+		if _, candidate := assembleFixes(code, append(kept, group), imports); v.parses(candidate, g) {
+			kept = append(kept, group)
+		}
+	}
+	return assembleFixes(code, kept, imports)
+}
+
+// parses reports whether code parses with grammar g without syntax errors.
+func (v *Validator) parses(code string, g grammar) bool {
+	tree, err := v.parse([]byte(code), g)
+	if err != nil {
+		return false
+	}
+	defer tree.Close()
+	return !tree.RootNode().HasError()
+}
+
+// planFixes builds a fix group for every violation that has a deterministic
+// fix and that fix accepts (every one if fix is nil), links it to the
+// violation's index, and returns the groups that can be applied together, in
+// source order.
+func planFixes(code string, violations []Violation, index *catalog.CatalogIndex, fix func(Violation) bool) []fixGroup {
+	var groups []fixGroup
+	for i, v := range violations {
+		if fix != nil && !fix(v) {
+			continue
+		}
+		var group fixGroup
+		if len(v.Edits) > 0 {
+			// A rule that supplies its own edits decides its fix.
+			group.fixes = editFixes(code, v)
+			if len(group.fixes) > 0 {
+				group.link(i)
+				groups = append(groups, group)
+			}
+			continue
+		}
 		switch v.Rule {
 		case "wrong-import-path":
-			fix := fixWrongImportPath(v, lines, index)
-			if fix != nil {
-				fixes = append(fixes, *fix)
+			comp, ok := index.ComponentByName[v.Component]
+			if !ok || v.usage == nil || v.imp == nil {
+				continue
 			}
+			group.imports = []neededImport{{
+				name: v.Component, path: comp.ImportPath, rule: v.Rule,
+				local: v.usage.bindingName(), from: v.imp,
+			}}
 
 		case "missing-import":
-			comp, ok := index.ComponentByName[v.Component]
-			if ok {
-				importLine := fmt.Sprintf("import { %s } from %q", v.Component, comp.ImportPath)
-				missingImports = append(missingImports, importLine)
+			if comp, ok := index.ComponentByName[v.Component]; ok {
+				group.imports = []neededImport{{name: v.Component, path: comp.ImportPath, rule: v.Rule}}
 			}
 
 		case "invalid-prop-value":
-			fix := fixInvalidPropValue(v, lines, index)
-			if fix != nil {
-				fixes = append(fixes, *fix)
+			group.fixes = fixInvalidPropValue(code, v, index)
+
+		case "use-catalog-components":
+			comp, ok := index.ComponentByName[v.Component]
+			if !ok || v.usage == nil {
+				continue
+			}
+			reason := fmt.Sprintf("Replace <%s> with <%s>", v.usage.ComponentName, comp.Name)
+			group.fixes = renameElement(code, v, comp.Name, reason)
+			if len(group.fixes) > 0 {
+				group.imports = importFor(code, index, comp.Name, v.Rule)
+			}
+
+		case "unknown-component":
+			if v.replacement == "" || v.usage == nil {
+				continue
+			}
+			reason := fmt.Sprintf("Rename <%s> to <%s>", v.usage.ComponentName, v.replacement)
+			group.fixes = renameElement(code, v, v.replacement, reason)
+			if len(group.fixes) > 0 {
+				group.imports = importFor(code, index, v.replacement, v.Rule)
+			}
+
+		case "deprecated-component":
+			if v.replacement == "" || v.usage == nil {
+				continue
+			}
+			reason := fmt.Sprintf("Replace deprecated <%s> with <%s>", v.usage.ComponentName, v.replacement)
+			group.fixes = renameElement(code, v, v.replacement, reason)
+			if len(group.fixes) > 0 {
+				group.imports = importFor(code, index, v.replacement, v.Rule)
+			}
+
+		case "unknown-prop":
+			group.fixes = renameProp(code, v, fmt.Sprintf("Rename prop %s to %s", v.prop, v.replacement))
+
+		case "deprecated-prop":
+			group.fixes = renameProp(code, v, fmt.Sprintf("Replace deprecated prop %s with %s", v.prop, v.replacement))
+
+		case "missing-required-prop":
+			group.fixes = fixMissingProp(code, v, index)
+
+		case "missing-child":
+			group.fixes = fixMissingChild(code, v, index)
+			if len(group.fixes) > 0 {
+				group.imports = importFor(code, index, v.replacement, v.Rule)
+			}
+
+		case "composition-violation":
+			group.fixes = wrapElement(code, v)
+			if len(group.fixes) > 0 {
+				group.imports = importFor(code, index, v.replacement, v.Rule)
 			}
 		}
+		if len(group.fixes) > 0 || len(group.imports) > 0 {
+			group.link(i)
+			groups = append(groups, group)
+		}
 	}
+	return selectFixes(code, groups)
+}
 
-	// Deduplicate missing imports by import path.
-	if len(missingImports) > 0 {
-		deduped := deduplicateImports(missingImports)
-		insertLine := lastImportLine + 1
-		if insertLine < 1 {
-			insertLine = 1
+// selectFixes drops invalid fixes and resolves overlaps. Groups are taken in
+// source order; a group is skipped if any of its fixes overlaps a fix already
+// taken, and fixes identical to one already taken are dropped as duplicates.
+func selectFixes(code string, groups []fixGroup) []fixGroup {
+	valid := groups[:0]
+	for _, group := range groups {
+		ok := true
+		for _, fix := range group.fixes {
+			if fix.StartByte < 0 || fix.EndByte < fix.StartByte || fix.EndByte > len(code) ||
+				code[fix.StartByte:fix.EndByte] != fix.OldText {
+				ok = false
+				break
+			}
 		}
+		if ok {
+			sortFixes(group.fixes)
+			valid = append(valid, group)
+		}
+	}
+
+	sort.SliceStable(valid, func(i, j int) bool {
+		return groupLess(valid[i], valid[j])
+	})
+
+	var taken []AutoFix
+	var selected []fixGroup
+	for _, group := range valid {
+		var fixes []AutoFix
+		conflict := false
+		for _, fix := range group.fixes {
+			duplicate := false
+			for _, t := range taken {
+				if fix.StartByte == t.StartByte && fix.EndByte == t.EndByte && fix.NewText == t.NewText {
+					duplicate = true
+					break
+				}
+				if overlaps(fix, t) {
+					conflict = true
+					break
+				}
+			}
+			if conflict {
+				break
+			}
+			if !duplicate {
+				fixes = append(fixes, fix)
+			}
+		}
+		if conflict || (len(fixes) == 0 && len(group.imports) == 0) {
+			continue
+		}
+		taken = append(taken, fixes...)
+		selected = append(selected, fixGroup{fixes: fixes, imports: group.imports})
+	}
+	return selected
+}
+
+// groupLess orders fix groups by their first fix; groups with no fixes (only
+// imports) come first.
+func groupLess(a, b fixGroup) bool {
+	switch {
+	case len(a.fixes) == 0 || len(b.fixes) == 0:
+		return len(a.fixes) < len(b.fixes)
+	}
+	return fixLess(a.fixes[0], b.fixes[0])
+}
+
+// fixLess orders fixes by range, then by replacement text.
+func fixLess(a, b AutoFix) bool {
+	if a.StartByte != b.StartByte {
+		return a.StartByte < b.StartByte
+	}
+	if a.EndByte != b.EndByte {
+		return a.EndByte < b.EndByte
+	}
+	return a.NewText < b.NewText
+}
 
-		for _, imp := range deduped {
-			fixes = append(fixes, AutoFix{
-				Line:    insertLine,
-				Column:  1,
-				OldText: "",
-				NewText: imp,
-				Rule:    "missing-import",
-				Reason:  "Add missing import",
-			})
+// sortFixes sorts fixes by position, keeping insertions at the same offset
+// in the order given.
+func sortFixes(fixes []AutoFix) {
+	sort.SliceStable(fixes, func(i, j int) bool {
+		if fixes[i].StartByte != fixes[j].StartByte {
+			return fixes[i].StartByte < fixes[j].StartByte
 		}
+		return fixes[i].EndByte < fixes[j].EndByte
+	})
+}
+
+// overlaps reports whether two fixes touch the same source. Ranges are
+// half-open, so adjacent replacements don't overlap, and an insertion
+// conflicts only with a replacement that spans its offset. Insertions at the
+// same offset are applied in order.
+func overlaps(a, b AutoFix) bool {
+	aInsert, bInsert := a.StartByte == a.EndByte, b.StartByte == b.EndByte
+	switch {
+	case aInsert && bInsert:
+		return false
+	case aInsert:
+		return b.StartByte < a.StartByte && a.StartByte < b.EndByte
+	case bInsert:
+		return a.StartByte < b.StartByte && b.StartByte < a.EndByte
 	}
+	return a.StartByte < b.EndByte && b.StartByte < a.EndByte
+}
+
+// assembleFixes turns fix groups into the final fixes, adding the imports
+// they need to the statements in imports or as new statements, and applies
+// them.
+func assembleFixes(code string, groups []fixGroup, imports []ImportInfo) ([]AutoFix, string) {
+	var fixes []AutoFix
+	var needs []neededImport
+	for _, group := range groups {
+		fixes = append(fixes, group.fixes...)
+		needs = append(needs, group.imports...)
+	}
+	fixes = append(fixes, planImportFixes(code, imports, needs, importsEnd(code, imports))...)
 
 	if len(fixes) == 0 {
 		return nil, ""
 	}
+	sortFixes(fixes)
+	return fixes, applyFixes(code, fixes)
+}
 
-	fixedCode := applyFixes(code, fixes)
-	return fixes, fixedCode
+// newFix creates a fix replacing the source in s with text.
+func newFix(code string, s span, text, rule, reason, component string) AutoFix {
+	line, col := position(code, s.start)
+	endLine, endCol := position(code, s.end)
+	return AutoFix{
+		Line:      line,
+		Column:    col,
+		EndLine:   endLine,
+		EndColumn: endCol,
+		StartByte: s.start,
+		EndByte:   s.end,
+		OldText:   code[s.start:s.end],
+		NewText:   text,
+		Rule:      rule,
+		Reason:    reason,
+		Component: component,
+	}
 }
 
-// fixWrongImportPath generates a fix for a wrong import path.
-func fixWrongImportPath(v Violation, lines []string, index *catalog.CatalogIndex) *AutoFix {
-	comp, ok := index.ComponentByName[v.Component]
+// position returns the 1-based line and byte column of a byte offset.
+func position(code string, offset int) (line, column int) {
+	line = 1 + strings.Count(code[:offset], "\n")
+	column = offset - strings.LastIndexByte(code[:offset], '\n')
+	return line, column
+}
+
+// fixInvalidPropValue generates a fix for an invalid prop value. The value is
+// replaced where it is written in the attribute, including inside a ternary
+// or logical expression; values from a const elsewhere in the file are left
+// alone.
+func fixInvalidPropValue(code string, v Violation, index *catalog.CatalogIndex) []AutoFix {
+	if v.usage == nil || v.prop == "" || v.value == "" {
+		return nil
+	}
+	attr, ok := v.usage.attrs[v.prop]
 	if !ok {
 		return nil
 	}
 
-	// Find the import line containing this component.
-	for i, line := range lines {
-		if strings.Contains(line, v.Component) && strings.Contains(line, "import") {
-			// Find the old import path in the line.
-			// Look for string content between quotes.
-			for _, quote := range []byte{'"', '\''} {
-				start := strings.IndexByte(line, quote)
-				if start < 0 {
-					continue
-				}
-				end := strings.IndexByte(line[start+1:], quote)
-				if end < 0 {
-					continue
-				}
-				oldPath := line[start+1 : start+1+end]
-				if oldPath != comp.ImportPath {
-					return &AutoFix{
-						Line:    i + 1,
-						Column:  start + 2,
-						OldText: oldPath,
-						NewText: comp.ImportPath,
-						Rule:    "wrong-import-path",
-						Reason:  fmt.Sprintf("Fix import path for %s", v.Component),
-					}
-				}
-			}
+	var propDef *catalog.Prop
+	props := propsFor(index, v.Component)
+	for i := range props {
+		if props[i].Name == v.prop {
+			propDef = &props[i]
+			break
 		}
 	}
-	return nil
-}
+	if propDef == nil {
+		return nil
+	}
 
-// fixInvalidPropValue generates a fix for an invalid prop value.
-func fixInvalidPropValue(v Violation, lines []string, index *catalog.CatalogIndex) *AutoFix {
-	comp, ok := index.ComponentByName[v.Component]
+	// Prefer the close match to a misspelled value over the default.
+	replacement := v.replacement
+	if replacement == "" {
+		replacement = propDef.Default
+	}
+	if replacement == "" {
+		return nil
+	}
+
+	literal, ok := valueLiteral(code, attr, v.value)
 	if !ok {
 		return nil
 	}
+	return []AutoFix{newFix(code, literal, replacement, "invalid-prop-value",
+		fmt.Sprintf("Fix %s value from %q to %q", propDef.Name, v.value, replacement), v.Component)}
+}
 
-	// Find the prop with a default value.
-	var propDef *catalog.Prop
-	for i := range comp.Props {
-		// Extract prop name from the message.
-		if strings.Contains(v.Message, fmt.Sprintf("Prop %q", comp.Props[i].Name)) {
-			propDef = &comp.Props[i]
-			break
+// valueLiteral returns the only string literal in the attribute whose
+// contents are value. ok is false if there is none or more than one.
+func valueLiteral(code string, attr attrSpan, value string) (span, bool) {
+	var found span
+	count := 0
+	for _, s := range attr.literals {
+		if code[s.start:s.end] == value {
+			found = s
+			count++
+		}
+	}
+	return found, count == 1
+}
+
+// editFixes converts the edits a rule attached to a violation into fixes.
+// If any edit lies outside the code, none are used.
+func editFixes(code string, v Violation) []AutoFix {
+	reason := v.Suggestion
+	if reason == "" {
+		reason = v.Message
+	}
+	fixes := make([]AutoFix, 0, len(v.Edits))
+	for _, e := range v.Edits {
+		if e.StartByte < 0 || e.EndByte < e.StartByte || e.EndByte > len(code) {
+			return nil
 		}
+		fixes = append(fixes, newFix(code, span{start: e.StartByte, end: e.EndByte}, e.NewText, v.Rule, reason, v.Component))
 	}
+	return fixes
+}
 
-	if propDef == nil || propDef.Default == "" {
+// renameProp generates a fix renaming the violation's prop to its
+// replacement, e.g. varient= to variant=.
+func renameProp(code string, v Violation, reason string) []AutoFix {
+	if v.replacement == "" || v.usage == nil {
+		return nil
+	}
+	attr, ok := v.usage.attrs[v.prop]
+	if !ok || attr.name.empty() {
 		return nil
 	}
+	return []AutoFix{newFix(code, attr.name, v.replacement, v.Rule, reason, v.Component)}
+}
 
-	// Find the invalid value in the violation message.
-	// Message format: Prop "X" on "Y" has invalid value "Z" (allowed: ...)
-	invalidValue := extractQuotedValue(v.Message, "invalid value")
-	if invalidValue == "" {
+// fixMissingProp generates a fix adding a missing required prop with its
+// catalog default right after the tag name.
+func fixMissingProp(code string, v Violation, index *catalog.CatalogIndex) []AutoFix {
+	if v.usage == nil || v.usage.tagSpan.empty() {
 		return nil
 	}
+	var def *catalog.Prop
+	props := propsFor(index, v.Component)
+	for i := range props {
+		if props[i].Name == v.prop {
+			def = &props[i]
+			break
+		}
+	}
+	if def == nil || def.Default == "" {
+		return nil
+	}
+
+	at := span{start: v.usage.tagSpan.end, end: v.usage.tagSpan.end}
+	text := " " + def.Name + "=" + attributeValue(def)
+	return []AutoFix{newFix(code, at, text, v.Rule, fmt.Sprintf("Add %s=%s", def.Name, attributeValue(def)), v.Component)}
+}
 
-	return &AutoFix{
-		Line:    v.Line,
-		Column:  v.Column,
-		OldText: fmt.Sprintf(`"%s"`, invalidValue),
-		NewText: fmt.Sprintf(`"%s"`, propDef.Default),
-		Rule:    "invalid-prop-value",
-		Reason:  fmt.Sprintf("Fix %s value from %q to %q", propDef.Name, invalidValue, propDef.Default),
+// attributeValue renders a prop's default as a JSX attribute value: number
+// and boolean literals in braces unless the prop takes strings, anything else
+// as a string.
+func attributeValue(def *catalog.Prop) string {
+	value := def.Default
+	literal := isNumberLiteral(value) || value == "true" || value == "false"
+	if kinds, ok := acceptedKinds(def.Type); literal && ok && !kinds[ValueKindString] {
+		return "{" + value + "}"
 	}
+	if strings.Contains(value, `"`) {
+		return "{" + strconv.Quote(value) + "}"
+	}
+	return `"` + value + `"`
 }
 
-// extractQuotedValue finds a quoted value after a keyword in a message string.
-func extractQuotedValue(message, keyword string) string {
-	idx := strings.Index(message, keyword)
-	if idx < 0 {
-		return ""
+// fixMissingChild generates a fix inserting an empty required child at the
+// start of the element's children, on its own line if they are on their own
+// lines.
+func fixMissingChild(code string, v Violation, index *catalog.CatalogIndex) []AutoFix {
+	if v.replacement == "" || v.usage == nil || v.usage.closeTagSpan.empty() {
+		return nil
 	}
-	rest := message[idx+len(keyword):]
-	start := strings.IndexByte(rest, '"')
-	if start < 0 {
-		return ""
+	name := v.replacement
+
+	child := "<" + name + "></" + name + ">"
+	if sub, ok := index.SubComponentDef[name]; ok && sub.Children == catalog.ChildrenNone {
+		child = "<" + name + " />"
+	} else if comp, ok := index.ComponentByName[name]; ok && comp.Children == catalog.ChildrenNone {
+		child = "<" + name + " />"
 	}
-	end := strings.IndexByte(rest[start+1:], '"')
-	if end < 0 {
-		return ""
+
+	body := v.usage.bodySpan
+	text := child
+	if indent, ok := childIndent(code, body); ok {
+		text = "\n" + indent + child
 	}
-	return rest[start+1 : start+1+end]
+	at := span{start: body.start, end: body.start}
+	return []AutoFix{newFix(code, at, text, v.Rule, fmt.Sprintf("Add <%s> inside <%s>", name, v.Component), v.Component)}
 }
 
-// findLastImportLine returns the 1-based line number of the last import statement, or 0 if none.
-func findLastImportLine(lines []string) int {
-	last := 0
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "import ") || strings.HasPrefix(trimmed, "import{") {
-			last = i + 1
+// childIndent returns the indentation of an element's children when they
+// start on a new line: that of the first child, or one level deeper than the
+// closing tag if there are none.
+func childIndent(code string, body span) (string, bool) {
+	text := code[body.start:body.end]
+	if !strings.HasPrefix(strings.TrimLeft(text, " \t"), "\n") {
+		return "", false
+	}
+	for _, line := range strings.Split(text, "\n")[1:] {
+		if trimmed := strings.TrimLeft(line, " \t"); trimmed != "" {
+			return line[:len(line)-len(trimmed)], true
 		}
 	}
-	return last
+	return lineIndent(code, body.end) + "  ", true
 }
 
-// deduplicateImports removes duplicate import lines.
-func deduplicateImports(imports []string) []string {
-	seen := make(map[string]bool)
-	var result []string
-	for _, imp := range imports {
-		if !seen[imp] {
-			seen[imp] = true
-			result = append(result, imp)
-		}
+// wrapElement generates the fixes wrapping an element in its required
+// parent, e.g. <CardContent> in <Card>.
+func wrapElement(code string, v Violation) []AutoFix {
+	if v.replacement == "" || v.usage == nil || v.usage.span.empty() {
+		return nil
+	}
+	name := v.replacement
+	reason := fmt.Sprintf("Wrap <%s> in <%s>", v.usage.ComponentName, name)
+	return []AutoFix{
+		newFix(code, span{start: v.usage.span.start, end: v.usage.span.start}, "<"+name+">", v.Rule, reason, v.Component),
+		newFix(code, span{start: v.usage.span.end, end: v.usage.span.end}, "</"+name+">", v.Rule, reason, v.Component),
 	}
-	return result
 }
 
-// applyFixes applies all fixes to the code and returns the fixed code.
-func applyFixes(code string, fixes []AutoFix) string {
-	lines := strings.Split(code, "\n")
+// renameElement generates fixes that rename a JSX element's tag, including
+// the closing tag when there is one.
+func renameElement(code string, v Violation, name, reason string) []AutoFix {
+	if v.usage.tagSpan.empty() {
+		return nil
+	}
+	fixes := []AutoFix{newFix(code, v.usage.tagSpan, name, v.Rule, reason, v.Component)}
+	if !v.usage.closeTagSpan.empty() {
+		fixes = append(fixes, newFix(code, v.usage.closeTagSpan, name, v.Rule, reason, v.Component))
+	}
+	return fixes
+}
 
-	// Separate insertions from replacements.
-	var insertions []AutoFix
-	var replacements []AutoFix
-	for _, fix := range fixes {
-		if fix.OldText == "" {
-			insertions = append(insertions, fix)
-		} else {
-			replacements = append(replacements, fix)
+// importFor returns the import a fix that introduces name needs, if it is
+// not already imported. Dotted aliases are reached through their
+// already-imported root.
+func importFor(code string, index *catalog.CatalogIndex, name, rule string) []neededImport {
+	path := importPathFor(index, name)
+	if path == "" || hasImport(code, name, path) {
+		return nil
+	}
+	return []neededImport{{name: name, path: path, rule: rule}}
+}
+
+// hasImport reports whether an import line already brings name in from path.
+func hasImport(code, name, path string) bool {
+	for _, line := range strings.Split(code, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "import") && containsIdentifier(trimmed, name) && strings.Contains(trimmed, path) {
+			return true
 		}
 	}
+	return false
+}
 
-	// Apply replacements first (in reverse line order to preserve line numbers).
-	sort.Slice(replacements, func(i, j int) bool {
-		return replacements[i].Line > replacements[j].Line
-	})
-	for _, fix := range replacements {
-		lineIdx := fix.Line - 1
-		if lineIdx >= 0 && lineIdx < len(lines) {
-			lines[lineIdx] = strings.Replace(lines[lineIdx], fix.OldText, fix.NewText, 1)
+// containsIdentifier reports whether name appears in s as a whole
+// identifier, so that "Dialog" is not found in "DialogTrigger".
+func containsIdentifier(s, name string) bool {
+	for offset := 0; ; {
+		idx := strings.Index(s[offset:], name)
+		if idx < 0 {
+			return false
+		}
+		start, end := offset+idx, offset+idx+len(name)
+		if (start == 0 || !isIdentifierByte(s[start-1])) && (end == len(s) || !isIdentifierByte(s[end])) {
+			return true
 		}
+		offset = end
 	}
+}
 
-	// Apply insertions (in reverse line order).
-	sort.Slice(insertions, func(i, j int) bool {
-		return insertions[i].Line > insertions[j].Line
-	})
-	for _, fix := range insertions {
-		lineIdx := fix.Line - 1
-		if lineIdx < 0 {
-			lineIdx = 0
+// isIdentifierByte reports whether c can be part of an ASCII identifier.
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// importPathFor returns the import path of a component or sub-component, or
+// "" for names that are not imported directly (such as dotted aliases).
+func importPathFor(index *catalog.CatalogIndex, name string) string {
+	if comp, ok := index.ComponentByName[name]; ok {
+		return comp.ImportPath
+	}
+	if comp, ok := index.SubComponentByName[name]; ok {
+		return comp.ImportPath
+	}
+	return ""
+}
+
+// propsFor returns the catalog props of a component or sub-component.
+func propsFor(index *catalog.CatalogIndex, name string) []catalog.Prop {
+	if comp, ok := index.ComponentByName[name]; ok {
+		return comp.Props
+	}
+	if sub, ok := index.SubComponentDef[name]; ok {
+		return sub.Props
+	}
+	return nil
+}
+
+// importsEnd returns the byte offset where new import statements go: the
+// start of the line after the last import, or 0 if there are none. Without
+// parsed imports, import lines are found by scanning the code.
+func importsEnd(code string, imports []ImportInfo) int {
+	end := 0
+	if imports != nil {
+		for _, imp := range imports {
+			end = max(end, imp.span.end)
 		}
-		if lineIdx > len(lines) {
-			lineIdx = len(lines)
+	} else {
+		offset := 0
+		for _, line := range strings.SplitAfter(code, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "import ") || strings.HasPrefix(trimmed, "import{") {
+				end = offset + len(strings.TrimRight(line, "\n"))
+			}
+			offset += len(line)
 		}
-		// Insert a new line at this position.
-		newLines := make([]string, len(lines)+1)
-		copy(newLines, lines[:lineIdx])
-		newLines[lineIdx] = fix.NewText
-		copy(newLines[lineIdx+1:], lines[lineIdx:])
-		lines = newLines
 	}
+	if end == 0 {
+		return 0
+	}
+	if nl := strings.IndexByte(code[end:], '\n'); nl >= 0 {
+		return end + nl + 1
+	}
+	return len(code)
+}
 
-	return strings.Join(lines, "\n")
+// applyFixes applies non-overlapping fixes, sorted by position, to the code
+// and returns the fixed code.
+func applyFixes(code string, fixes []AutoFix) string {
+	var b strings.Builder
+	pos := 0
+	for _, fix := range fixes {
+		b.WriteString(code[pos:fix.StartByte])
+		b.WriteString(fix.NewText)
+		pos = fix.EndByte
+	}
+	b.WriteString(code[pos:])
+	return b.String()
 }
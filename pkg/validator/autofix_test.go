@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPosition(t *testing.T) {
+	code := "ab\ncde\n"
+
+	line, col := position(code, 0)
+	assert.Equal(t, []int{1, 1}, []int{line, col})
+	line, col = position(code, 4)
+	assert.Equal(t, []int{2, 2}, []int{line, col})
+	line, col = position(code, len(code))
+	assert.Equal(t, []int{3, 1}, []int{line, col})
+}
+
+func TestSelectFixes_Overlap(t *testing.T) {
+	code := `<Button variant="x">Go</Button>`
+	value := spanOf(code, "x")
+	closeTag := spanAt(code, "Button", 2)
+
+	groups := []fixGroup{
+		{fixes: []AutoFix{newFix(code, value, "default", "invalid-prop-value", "", "Button")}},
+		// Overlaps the value fix, so the whole group is dropped.
+		{fixes: []AutoFix{
+			newFix(code, span{start: value.start, end: value.end + 1}, `ghost"`, "other", "", "Button"),
+			newFix(code, closeTag, "Badge", "other", "", "Badge"),
+		}},
+		// Adjacent to the value fix, which is not an overlap.
+		{fixes: []AutoFix{newFix(code, span{start: value.end, end: value.end + 1}, `'`, "quote", "", "Button")}},
+		// Exact duplicate of the first fix.
+		{fixes: []AutoFix{newFix(code, value, "default", "invalid-prop-value", "", "Button")}},
+	}
+
+	selected := selectFixes(code, groups)
+
+	require.Len(t, selected, 2)
+	fixes, fixed := assembleFixes(code, selected, nil)
+	require.Len(t, fixes, 2)
+	assert.Equal(t, `<Button variant="default'>Go</Button>`, fixed)
+}
+
+func TestSelectFixes_StaleOldText(t *testing.T) {
+	code := `<Button size="sm" />`
+	fix := newFix(code, spanOf(code, "sm"), "lg", "invalid-prop-value", "", "Button")
+	fix.OldText = "md"
+
+	assert.Empty(t, selectFixes(code, []fixGroup{{fixes: []AutoFix{fix}}}))
+}
+
+func TestAssembleFixes_Deterministic(t *testing.T) {
+	code := "import { Card } from \"@/components/ui/card\"\n<Buton><Badg /></Buton>"
+	rename := func(from, to string, n int) fixGroup {
+		return fixGroup{
+			fixes:   []AutoFix{newFix(code, spanAt(code, from, n), to, "unknown-component", "", to)},
+			imports: []neededImport{{name: to, path: "@/components/ui/" + to, rule: "unknown-component"}},
+		}
+	}
+	groups := []fixGroup{rename("Badg", "Badge", 1), rename("Buton", "Button", 2), rename("Buton", "Button", 1)}
+	reversed := []fixGroup{groups[2], groups[1], groups[0]}
+
+	fixes, fixed := assembleFixes(code, selectFixes(code, groups), nil)
+	fixes2, fixed2 := assembleFixes(code, selectFixes(code, reversed), nil)
+
+	assert.Equal(t, fixed, fixed2)
+	assert.Equal(t, fixes, fixes2)
+	assert.Equal(t, "import { Card } from \"@/components/ui/card\"\n"+
+		"import { Button } from \"@/components/ui/Button\"\n"+
+		"import { Badge } from \"@/components/ui/Badge\"\n"+
+		"<Button><Badge /></Button>", fixed)
+}
+
+func TestImportsEnd(t *testing.T) {
+	assert.Equal(t, 0, importsEnd("<div />", nil))
+
+	code := "import { A } from \"a\"\nimport {\n  B,\n} from \"b\"\n\n<A />"
+	imports := []ImportInfo{
+		{span: spanOf(code, `import { A } from "a"`)},
+		{span: spanOf(code, "import {\n  B,\n} from \"b\"")},
+	}
+	assert.Equal(t, len("import { A } from \"a\"\nimport {\n  B,\n} from \"b\"\n"), importsEnd(code, imports))
+
+	// An import on the last line gets a newline before the insertion.
+	code = `import { A } from "a"`
+	fixes, fixed := assembleFixes(code, []fixGroup{{imports: []neededImport{{name: "B", path: "b"}}}}, nil)
+	require.Len(t, fixes, 1)
+	assert.Equal(t, "import { A } from \"a\"\nimport { B } from \"b\"\n", fixed)
+}
@@ -0,0 +1,292 @@
+package validator
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// rgbColor is a color in linear-light sRGB. Components are nominally 0–1 but
+// may fall outside that range for wide-gamut inputs such as oklch().
+type rgbColor struct {
+	r, g, b float64
+}
+
+// labColor is a color in CIE L*a*b* (D65 white point).
+type labColor struct {
+	l, a, b float64
+}
+
+// parseColor parses a CSS color literal: #rgb, #rgba, #rrggbb, #rrggbbaa,
+// rgb()/rgba(), hsl()/hsla(), oklch(), or a bare HSL triplet such as
+// "222 47% 11%" (the form shadcn/ui stores in CSS variables). Alpha is ignored.
+func parseColor(s string) (rgbColor, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return rgbColor{}, false
+	}
+
+	if strings.HasPrefix(s, "#") {
+		return parseHexColor(s[1:])
+	}
+
+	name, args, ok := splitColorFunc(s)
+	if !ok {
+		// Bare "H S% L%" triplet.
+		if parts := colorArgs(s); len(parts) == 3 && strings.HasSuffix(parts[1], "%") && strings.HasSuffix(parts[2], "%") {
+			return hslArgs(parts)
+		}
+		return rgbColor{}, false
+	}
+
+	parts := colorArgs(args)
+	if len(parts) < 3 {
+		return rgbColor{}, false
+	}
+
+	switch name {
+	case "rgb", "rgba":
+		var c [3]float64
+		for i := 0; i < 3; i++ {
+			v, pct, ok := parseNumber(parts[i])
+			if !ok {
+				return rgbColor{}, false
+			}
+			if pct {
+				c[i] = v / 100
+			} else {
+				c[i] = v / 255
+			}
+		}
+		return rgbColor{srgbToLinear(c[0]), srgbToLinear(c[1]), srgbToLinear(c[2])}, true
+	case "hsl", "hsla":
+		return hslArgs(parts)
+	case "oklch":
+		return oklchArgs(parts)
+	}
+	return rgbColor{}, false
+}
+
+// parseHexColor parses the digits of a #rgb, #rgba, #rrggbb, or #rrggbbaa color.
+func parseHexColor(hex string) (rgbColor, bool) {
+	switch len(hex) {
+	case 3, 4:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	case 6, 8:
+		hex = hex[:6]
+	default:
+		return rgbColor{}, false
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return rgbColor{}, false
+	}
+	return rgbColor{
+		srgbToLinear(float64(n>>16&0xff) / 255),
+		srgbToLinear(float64(n>>8&0xff) / 255),
+		srgbToLinear(float64(n&0xff) / 255),
+	}, true
+}
+
+// hslArgs converts hue, saturation, and lightness arguments to a color.
+func hslArgs(parts []string) (rgbColor, bool) {
+	h, _, ok1 := parseNumber(strings.TrimSuffix(parts[0], "deg"))
+	s, _, ok2 := parseNumber(parts[1])
+	l, _, ok3 := parseNumber(parts[2])
+	if !ok1 || !ok2 || !ok3 {
+		return rgbColor{}, false
+	}
+	h = math.Mod(math.Mod(h, 360)+360, 360) / 360
+	s, l = s/100, l/100
+
+	if s == 0 {
+		v := srgbToLinear(l)
+		return rgbColor{v, v, v}, true
+	}
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	return rgbColor{
+		srgbToLinear(hueToRGB(p, q, h+1.0/3)),
+		srgbToLinear(hueToRGB(p, q, h)),
+		srgbToLinear(hueToRGB(p, q, h-1.0/3)),
+	}, true
+}
+
+// hueToRGB is the HSL → RGB helper from the CSS Color specification.
+func hueToRGB(p, q, t float64) float64 {
+	if t < 0 {
+		t++
+	}
+	if t > 1 {
+		t--
+	}
+	switch {
+	case t < 1.0/6:
+		return p + (q-p)*6*t
+	case t < 1.0/2:
+		return q
+	case t < 2.0/3:
+		return p + (q-p)*(2.0/3-t)*6
+	}
+	return p
+}
+
+// oklchArgs converts OKLCH lightness, chroma, and hue arguments to a color.
+func oklchArgs(parts []string) (rgbColor, bool) {
+	l, lpct, ok1 := parseNumber(parts[0])
+	c, cpct, ok2 := parseNumber(parts[1])
+	h, _, ok3 := parseNumber(strings.TrimSuffix(parts[2], "deg"))
+	if !ok1 || !ok2 || !ok3 {
+		return rgbColor{}, false
+	}
+	if lpct {
+		l /= 100
+	}
+	if cpct {
+		c = c / 100 * 0.4
+	}
+
+	rad := h * math.Pi / 180
+	a, b := c*math.Cos(rad), c*math.Sin(rad)
+
+	// OKLab → LMS → linear sRGB.
+	lm := math.Pow(l+0.3963377774*a+0.2158037573*b, 3)
+	mm := math.Pow(l-0.1055613458*a-0.0638541728*b, 3)
+	sm := math.Pow(l-0.0894841775*a-1.2914855480*b, 3)
+	return rgbColor{
+		+4.0767416621*lm - 3.3077115913*mm + 0.2309699292*sm,
+		-1.2684380046*lm + 2.6097574011*mm - 0.3413193965*sm,
+		-0.0041960863*lm - 0.7034186147*mm + 1.7076147010*sm,
+	}, true
+}
+
+// splitColorFunc splits "rgb(1 2 3)" into its function name and arguments.
+func splitColorFunc(s string) (name, args string, ok bool) {
+	open := strings.IndexByte(s, '(')
+	if open <= 0 || !strings.HasSuffix(s, ")") {
+		return "", "", false
+	}
+	return strings.TrimSpace(s[:open]), s[open+1 : len(s)-1], true
+}
+
+// colorArgs splits color function arguments on commas, whitespace, and the
+// "/" alpha separator.
+func colorArgs(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '/' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+// parseNumber parses a number with an optional trailing "%".
+func parseNumber(s string) (value float64, percent bool, ok bool) {
+	if trimmed, found := strings.CutSuffix(s, "%"); found {
+		s, percent = trimmed, true
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false, false
+	}
+	return v, percent, true
+}
+
+// srgbToLinear removes the sRGB transfer function from a 0–1 component.
+func srgbToLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// lab converts a linear sRGB color to CIE L*a*b* under D65.
+func (c rgbColor) lab() labColor {
+	x := (0.4124564*c.r + 0.3575761*c.g + 0.1804375*c.b) / 0.95047
+	y := 0.2126729*c.r + 0.7151522*c.g + 0.0721750*c.b
+	z := (0.0193339*c.r + 0.1191920*c.g + 0.9503041*c.b) / 1.08883
+
+	f := func(t float64) float64 {
+		const epsilon, kappa = 216.0 / 24389, 24389.0 / 27
+		if t > epsilon {
+			return math.Cbrt(t)
+		}
+		return (kappa*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return labColor{l: 116*fy - 16, a: 500 * (fx - fy), b: 200 * (fy - fz)}
+}
+
+// deltaE2000 returns the CIEDE2000 perceptual difference between two colors.
+// Differences below roughly 2.3 are not noticeable to most viewers.
+func deltaE2000(c1, c2 labColor) float64 {
+	const pow25to7 = 6103515625.0 // 25^7
+	deg := func(rad float64) float64 { return rad * 180 / math.Pi }
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	cBar := (math.Hypot(c1.a, c1.b) + math.Hypot(c2.a, c2.b)) / 2
+	cBar7 := math.Pow(cBar, 7)
+	g := 0.5 * (1 - math.Sqrt(cBar7/(cBar7+pow25to7)))
+
+	a1, a2 := (1+g)*c1.a, (1+g)*c2.a
+	cp1, cp2 := math.Hypot(a1, c1.b), math.Hypot(a2, c2.b)
+
+	hue := func(b, a float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+		h := deg(math.Atan2(b, a))
+		if h < 0 {
+			h += 360
+		}
+		return h
+	}
+	hp1, hp2 := hue(c1.b, a1), hue(c2.b, a2)
+
+	dL := c2.l - c1.l
+	dC := cp2 - cp1
+
+	var dh float64
+	if cp1*cp2 != 0 {
+		dh = hp2 - hp1
+		switch {
+		case dh > 180:
+			dh -= 360
+		case dh < -180:
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(cp1*cp2) * math.Sin(rad(dh)/2)
+
+	lBar := (c1.l + c2.l) / 2
+	cpBar := (cp1 + cp2) / 2
+
+	hBar := hp1 + hp2
+	if cp1*cp2 != 0 {
+		switch {
+		case math.Abs(hp1-hp2) <= 180:
+			hBar /= 2
+		case hBar < 360:
+			hBar = (hBar + 360) / 2
+		default:
+			hBar = (hBar - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos(rad(hBar-30)) + 0.24*math.Cos(rad(2*hBar)) +
+		0.32*math.Cos(rad(3*hBar+6)) - 0.20*math.Cos(rad(4*hBar-63))
+
+	dTheta := 30 * math.Exp(-math.Pow((hBar-275)/25, 2))
+	cpBar7 := math.Pow(cpBar, 7)
+	rc := 2 * math.Sqrt(cpBar7/(cpBar7+pow25to7))
+	lBar50 := (lBar - 50) * (lBar - 50)
+	sl := 1 + 0.015*lBar50/math.Sqrt(20+lBar50)
+	sc := 1 + 0.045*cpBar
+	sh := 1 + 0.015*cpBar*t
+	rt := -math.Sin(rad(2*dTheta)) * rc
+
+	l, c, h := dL/sl, dC/sc, dH/sh
+	return math.Sqrt(l*l + c*c + h*h + rt*c*h)
+}
@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColor(t *testing.T) {
+	red := rgbColor{1, 0, 0}
+	tests := []struct {
+		input string
+		want  rgbColor
+		ok    bool
+	}{
+		{"#f00", red, true},
+		{"#FF0000", red, true},
+		{"#ff0000cc", red, true},
+		{"rgb(255, 0, 0)", red, true},
+		{"rgba(255 0 0 / 50%)", red, true},
+		{"rgb(100% 0% 0%)", red, true},
+		{"hsl(0, 100%, 50%)", red, true},
+		{"hsl(360deg 100% 50%)", red, true},
+		{"0 100% 50%", red, true},
+		{"#ff000", rgbColor{}, false},
+		{"hsl(var(--primary))", rgbColor{}, false},
+		{"red", rgbColor{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := parseColor(tt.input)
+			require.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.want.r, got.r, 1e-9)
+			assert.InDelta(t, tt.want.g, got.g, 1e-9)
+			assert.InDelta(t, tt.want.b, got.b, 1e-9)
+		})
+	}
+}
+
+func TestParseColor_OKLCH(t *testing.T) {
+	// oklch(0.628 0.2577 29.23) is sRGB red.
+	got, ok := parseColor("oklch(0.628 0.2577 29.23)")
+	require.True(t, ok)
+	red, _ := parseColor("#ff0000")
+	assert.Less(t, deltaE2000(got.lab(), red.lab()), 0.5)
+
+	pct, ok := parseColor("oklch(62.8% 0.2577 29.23deg)")
+	require.True(t, ok)
+	assert.InDelta(t, got.r, pct.r, 1e-9)
+}
+
+func TestDeltaE2000(t *testing.T) {
+	// Reference pairs from Sharma, Wu & Dalal, "The CIEDE2000 Color-Difference Formula".
+	tests := []struct {
+		c1, c2 labColor
+		want   float64
+	}{
+		{labColor{50, 2.6772, -79.7751}, labColor{50, 0, -82.7485}, 2.0425},
+		{labColor{50, 2.5, 0}, labColor{50, 0, -2.5}, 4.3065},
+		{labColor{50, 2.5, 0}, labColor{73, 25, -18}, 27.1492},
+		{labColor{60.2574, -34.0099, 36.2677}, labColor{60.4626, -34.1751, 39.4387}, 1.2644},
+		{labColor{2.0776, 0.0795, -1.135}, labColor{0.9033, -0.0636, -0.5514}, 0.9082},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, deltaE2000(tt.c1, tt.c2), 1e-4)
+		assert.InDelta(t, tt.want, deltaE2000(tt.c2, tt.c1), 1e-4)
+	}
+}
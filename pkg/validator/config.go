@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// SeverityOff disables a rule entirely.
+const SeverityOff = "off"
+
+// validLevels lists the values accepted in a RuleConfig.
+var validLevels = map[string]bool{
+	SeverityOff: true,
+	"info":      true,
+	"warning":   true,
+	"error":     true,
+}
+
+// RuleConfig overrides built-in rule severities, globally and per file glob.
+type RuleConfig struct {
+	// Rules maps rule id → "off", "info", "warning", or "error".
+	Rules map[string]string `json:"rules,omitempty"`
+	// Overrides apply additional rule levels to files matching their globs.
+	// Later overrides take precedence over earlier ones and over Rules.
+	Overrides []RuleOverride `json:"overrides,omitempty"`
+}
+
+// RuleOverride applies rule levels to files matching any of its globs.
+type RuleOverride struct {
+	// Files are doublestar globs matched against the page's filename,
+	// relative to the project root (e.g. "src/legacy/**").
+	Files []string          `json:"files"`
+	Rules map[string]string `json:"rules"`
+}
+
+// Validate checks that every rule id is known, every level is valid, and
+// every glob is well-formed. Returns an empty slice if the config is valid.
+func (c *RuleConfig) Validate() []error {
+	var errs []error
+
+	checkRules := func(where string, rules map[string]string) {
+		ids := make([]string, 0, len(rules))
+		for id := range rules {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			if _, ok := LookupRule(id); !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown rule %q", where, id))
+			}
+			if !validLevels[rules[id]] {
+				errs = append(errs, fmt.Errorf("%s: rule %q has invalid level %q (must be off/info/warning/error)", where, id, rules[id]))
+			}
+		}
+	}
+
+	checkRules("rules", c.Rules)
+	for i, o := range c.Overrides {
+		where := fmt.Sprintf("overrides[%d]", i)
+		if len(o.Files) == 0 {
+			errs = append(errs, fmt.Errorf("%s: files must have at least one glob", where))
+		}
+		for _, pattern := range o.Files {
+			if !doublestar.ValidatePattern(pattern) {
+				errs = append(errs, fmt.Errorf("%s: invalid glob %q", where, pattern))
+			}
+		}
+		checkRules(where+".rules", o.Rules)
+	}
+
+	return errs
+}
+
+// levelFor returns the configured level for a rule in the given file, and
+// whether any configuration applies.
+func (c *RuleConfig) levelFor(rule, filename string) (string, bool) {
+	level, ok := c.Rules[rule]
+
+	if filename != "" {
+		path := filepath.ToSlash(filename)
+		for _, o := range c.Overrides {
+			l, has := o.Rules[rule]
+			if !has || !matchesAny(o.Files, path) {
+				continue
+			}
+			level, ok = l, true
+		}
+	}
+
+	return level, ok
+}
+
+// apply rewrites violation severities according to the config and drops
+// violations of rules that are turned off.
+func (c *RuleConfig) apply(violations []Violation, filename string) []Violation {
+	if c == nil || (len(c.Rules) == 0 && len(c.Overrides) == 0) {
+		return violations
+	}
+
+	kept := violations[:0]
+	for _, v := range violations {
+		if level, ok := c.levelFor(v.Rule, filename); ok {
+			if level == SeverityOff {
+				continue
+			}
+			v.Severity = level
+		}
+		kept = append(kept, v)
+	}
+	return kept
+}
+
+// matchesAny reports whether path matches any of the globs.
+func matchesAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := doublestar.Match(pattern, path); matched {
+			return true
+		}
+	}
+	return false
+}
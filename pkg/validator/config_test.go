@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleConfig_Validate(t *testing.T) {
+	cfg := &RuleConfig{
+		Rules: map[string]string{
+			"invalid-prop-value": "error",
+			"unknown-prop":       "loud",
+			"no-such-rule":       "off",
+		},
+		Overrides: []RuleOverride{
+			{Files: nil, Rules: map[string]string{"unknown-prop": "off"}},
+			{Files: []string{"src/[legacy/**"}, Rules: map[string]string{}},
+		},
+	}
+
+	errs := cfg.Validate()
+	require.Len(t, errs, 4)
+	assert.Contains(t, errs[0].Error(), `unknown rule "no-such-rule"`)
+	assert.Contains(t, errs[1].Error(), `invalid level "loud"`)
+	assert.Contains(t, errs[2].Error(), "overrides[0]: files")
+	assert.Contains(t, errs[3].Error(), "overrides[1]: invalid glob")
+
+	assert.Empty(t, (&RuleConfig{Rules: map[string]string{"unknown-prop": "off"}}).Validate())
+}
+
+func TestRuleConfig_Apply(t *testing.T) {
+	cfg := &RuleConfig{
+		Rules: map[string]string{
+			"invalid-prop-value": "error",
+			"unknown-prop":       "off",
+		},
+		Overrides: []RuleOverride{
+			{Files: []string{"src/legacy/**"}, Rules: map[string]string{"invalid-prop-value": "info"}},
+			{Files: []string{"src/legacy/keep/**"}, Rules: map[string]string{"invalid-prop-value": "warning"}},
+		},
+	}
+	violations := func() []Violation {
+		return []Violation{
+			{Rule: "invalid-prop-value", Severity: "warning"},
+			{Rule: "unknown-prop", Severity: "info"},
+			{Rule: "missing-import", Severity: "error"},
+		}
+	}
+
+	got := cfg.apply(violations(), "src/pages/home.tsx")
+	require.Len(t, got, 2)
+	assert.Equal(t, "error", got[0].Severity)
+	assert.Equal(t, "missing-import", got[1].Rule)
+
+	got = cfg.apply(violations(), "src/legacy/old.tsx")
+	assert.Equal(t, "info", got[0].Severity)
+
+	got = cfg.apply(violations(), "src/legacy/keep/old.tsx")
+	assert.Equal(t, "warning", got[0].Severity)
+}
+
+func TestRuleConfig_NilApply(t *testing.T) {
+	var cfg *RuleConfig
+	in := []Violation{{Rule: "unknown-prop", Severity: "info"}}
+	assert.Equal(t, in, cfg.apply(in, "a.tsx"))
+}
+
+func TestValidatePage_RuleConfig(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+	v.SetRuleConfig(&RuleConfig{
+		Rules: map[string]string{"invalid-prop-value": "error"},
+		Overrides: []RuleOverride{
+			{Files: []string{"legacy/**"}, Rules: map[string]string{"invalid-prop-value": "off"}},
+		},
+	})
+
+	code := `
+import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  return <Button variant="fancy">Click</Button>
+}
+`
+	result := v.ValidatePageWithOptions(code, ValidateOptions{Filename: "app/page.tsx"})
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "error", result.Violations[0].Severity)
+	assert.False(t, result.Valid)
+
+	result = v.ValidatePageWithOptions(code, ValidateOptions{Filename: "legacy/page.tsx"})
+	assert.Empty(t, result.Violations)
+	assert.True(t, result.Valid)
+}
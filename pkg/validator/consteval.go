@@ -0,0 +1,181 @@
+package validator
+
+import (
+	"strings"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Limits that keep constant evaluation cheap on pathological input.
+const (
+	maxEvalDepth  = 8  // nested const references and expressions
+	maxEvalValues = 16 // possible values tracked per expression
+)
+
+// constEvaluator resolves prop expressions to the set of string literals they
+// can produce, following ternary and logical branches, template literals, and
+// file-level const bindings.
+type constEvaluator struct {
+	source []byte
+	consts map[string]*ts.Node // const name → initializer
+}
+
+// newConstEvaluator collects the file-level const declarations under root.
+func newConstEvaluator(root *ts.Node, source []byte) *constEvaluator {
+	e := &constEvaluator{source: source, consts: make(map[string]*ts.Node)}
+	for i := uint(0); i < uint(root.ChildCount()); i++ {
+		stmt := root.Child(i)
+		if stmt.Kind() == "export_statement" {
+			if decl := stmt.ChildByFieldName("declaration"); decl != nil {
+				stmt = decl
+			}
+		}
+		if stmt.Kind() != "lexical_declaration" || !strings.HasPrefix(stmt.Utf8Text(source), "const") {
+			continue
+		}
+		for j := uint(0); j < uint(stmt.ChildCount()); j++ {
+			decl := stmt.Child(j)
+			if decl.Kind() != "variable_declarator" {
+				continue
+			}
+			name, value := decl.ChildByFieldName("name"), decl.ChildByFieldName("value")
+			if name != nil && value != nil && name.Kind() == "identifier" {
+				e.consts[name.Utf8Text(source)] = value
+			}
+		}
+	}
+	return e
+}
+
+// evaluate returns the string values node can produce. complete is false when
+// some branch could not be resolved; the values found are still returned.
+func (e *constEvaluator) evaluate(node *ts.Node) (values []string, complete bool) {
+	return e.eval(node, 0)
+}
+
+func (e *constEvaluator) eval(node *ts.Node, depth int) ([]string, bool) {
+	if node == nil || depth > maxEvalDepth {
+		return nil, false
+	}
+
+	switch node.Kind() {
+	case "jsx_expression", "template_substitution", "parenthesized_expression", "as_expression", "satisfies_expression", "non_null_expression":
+		// Unwrap {expr}, ${expr}, (expr), expr as const, expr satisfies T, and expr!.
+		for i := uint(0); i < uint(node.ChildCount()); i++ {
+			if child := node.Child(i); child.IsNamed() && child.Kind() != "comment" {
+				return e.eval(child, depth+1)
+			}
+		}
+		return nil, false
+
+	case "string":
+		return []string{extractStringContent(node, e.source)}, true
+
+	case "template_string":
+		return e.evalTemplate(node, depth)
+
+	case "ternary_expression":
+		return e.union(depth, node.ChildByFieldName("consequence"), node.ChildByFieldName("alternative"))
+
+	case "binary_expression":
+		op := node.ChildByFieldName("operator")
+		if op == nil {
+			return nil, false
+		}
+		switch op.Kind() {
+		case "&&":
+			// cond && "value": the falsy branch passes a boolean, not a string.
+			return e.eval(node.ChildByFieldName("right"), depth+1)
+		case "||", "??":
+			return e.union(depth, node.ChildByFieldName("left"), node.ChildByFieldName("right"))
+		}
+		return nil, false
+
+	case "identifier":
+		if init, ok := e.consts[node.Utf8Text(e.source)]; ok {
+			return e.eval(init, depth+1)
+		}
+		return nil, false
+	}
+
+	return nil, false
+}
+
+// union evaluates each branch and merges their values.
+func (e *constEvaluator) union(depth int, branches ...*ts.Node) ([]string, bool) {
+	var values []string
+	complete := true
+	for _, branch := range branches {
+		v, ok := e.eval(branch, depth+1)
+		complete = complete && ok
+		values = appendUnique(values, v...)
+	}
+	if len(values) > maxEvalValues {
+		return values[:maxEvalValues], false
+	}
+	return values, complete
+}
+
+// evalTemplate expands a template literal, combining the possible values of
+// each ${...} substitution. Literal text is read from the source between
+// substitutions, so it does not depend on how the grammar splits fragments.
+func (e *constEvaluator) evalTemplate(node *ts.Node, depth int) ([]string, bool) {
+	values := []string{""}
+	complete := true
+
+	combine := func(parts []string) bool {
+		next := make([]string, 0, len(values)*len(parts))
+		for _, prefix := range values {
+			for _, part := range parts {
+				next = appendUnique(next, prefix+part)
+			}
+		}
+		if len(next) > maxEvalValues {
+			values = next[:maxEvalValues]
+			return false
+		}
+		values = next
+		return true
+	}
+
+	pos := node.StartByte() + 1 // skip the opening backtick
+	for i := uint(0); i < uint(node.ChildCount()); i++ {
+		child := node.Child(i)
+		if child.Kind() != "template_substitution" {
+			continue
+		}
+		if !combine([]string{string(e.source[pos:child.StartByte()])}) {
+			return values, false
+		}
+		parts, ok := e.eval(child, depth+1)
+		if len(parts) == 0 {
+			return nil, false
+		}
+		complete = complete && ok
+		if !combine(parts) {
+			return values, false
+		}
+		pos = child.EndByte()
+	}
+	if end := node.EndByte() - 1; end > pos {
+		combine([]string{string(e.source[pos:end])})
+	}
+	return values, complete
+}
+
+// appendUnique appends each value not already present in values.
+func appendUnique(values []string, add ...string) []string {
+	for _, a := range add {
+		found := false
+		for _, v := range values {
+			if v == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, a)
+		}
+	}
+	return values
+}
@@ -0,0 +1,244 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gnana997/uispec/pkg/catalog"
+)
+
+// constraintRule enforces the catalog's constraints between props:
+// requires, conflicts_with, required_when and allowed_values_when.
+// Unmet required_when and allowed_values_when constraints are reported as
+// missing-required-prop and invalid-prop-value.
+type constraintRule struct{}
+
+func (constraintRule) Info() []RuleInfo {
+	return []RuleInfo{
+		{ID: "prop-requires", Description: "A prop is set without a prop or children shape it requires", Severity: "error"},
+		{ID: "prop-conflict", Description: "A prop is set together with a prop or value it conflicts with", Severity: "error"},
+	}
+}
+
+func (constraintRule) Check(page *Page) []Violation {
+	var violations []Violation
+	for _, usage := range page.Extraction.Usages {
+		if comp, ok := page.Index.ComponentByName[usage.ComponentName]; ok {
+			violations = append(violations, checkConstraints(usage, comp.Props)...)
+		} else if subDef, ok := page.Index.SubComponentDef[usage.ComponentName]; ok {
+			violations = append(violations, checkConstraints(usage, subDef.Props)...)
+		}
+	}
+	return violations
+}
+
+// truth is the outcome of a condition that may depend on runtime values.
+type truth int
+
+const (
+	unknown truth = iota // e.g. the prop comes from a spread or an expression
+	holds
+	fails
+)
+
+// checkConstraints checks one usage against the constraints of its props.
+// Only conditions that certainly hold or fail are reported.
+func checkConstraints(usage JSXUsage, props []catalog.Prop) []Violation {
+	var violations []Violation
+
+	_, spread := usage.Props["...spread"]
+	reported := make(map[string]bool) // conflicting pairs, so each is reported once
+
+	violation := func(rule, message, suggestion string) Violation {
+		return Violation{
+			Rule:       rule,
+			Message:    message,
+			Severity:   "error",
+			Line:       usage.Line,
+			Column:     usage.Column,
+			Component:  usage.ComponentName,
+			Suggestion: suggestion,
+		}
+	}
+
+	for i := range props {
+		def := &props[i]
+		if _, set := usage.Props[def.Name]; !set {
+			if def.Required || spread {
+				continue
+			}
+			for _, c := range def.RequiredWhen {
+				if evalCondition(usage, c) != holds {
+					continue
+				}
+				v := violation("missing-required-prop",
+					fmt.Sprintf("Component %q is missing prop %q, required when %s", usage.ComponentName, def.Name, c),
+					"")
+				if def.Default != "" {
+					v.Suggestion = fmt.Sprintf("Add %s=%q", def.Name, def.Default)
+				}
+				v.usage = &usage
+				v.prop = def.Name
+				violations = append(violations, v)
+				break
+			}
+			continue
+		}
+
+		for _, c := range def.Requires {
+			if evalCondition(usage, c) == fails {
+				violations = append(violations, violation("prop-requires",
+					fmt.Sprintf("Prop %q on %q requires %s", def.Name, usage.ComponentName, requirement(c)),
+					requirementSuggestion(def.Name, c)))
+			}
+		}
+
+		for _, c := range def.ConflictsWith {
+			if evalCondition(usage, c) != holds {
+				continue
+			}
+			suggestion := fmt.Sprintf("Remove %s", def.Name)
+			if c.Prop != "" && len(c.Values) == 0 {
+				// Two props that conflict with each other are one problem.
+				pair := []string{def.Name, c.Prop}
+				sort.Strings(pair)
+				key := strings.Join(pair, "\x00")
+				if reported[key] {
+					continue
+				}
+				reported[key] = true
+				suggestion = fmt.Sprintf("Remove %s or %s", def.Name, c.Prop)
+			}
+			violations = append(violations, violation("prop-conflict",
+				fmt.Sprintf("Prop %q on %q cannot be used when %s", def.Name, usage.ComponentName, c),
+				suggestion))
+		}
+
+		for _, cv := range def.AllowedValuesWhen {
+			if evalCondition(usage, cv.When) != holds {
+				continue
+			}
+			for _, value := range knownValues(usage, def.Name) {
+				// Values outside allowed_values are already reported.
+				if containsName(cv.Values, value) || (len(def.AllowedValues) > 0 && !containsName(def.AllowedValues, value)) {
+					continue
+				}
+				v := violation("invalid-prop-value",
+					fmt.Sprintf("Prop %q on %q has invalid value %q when %s (allowed: %s)", def.Name, usage.ComponentName, value, cv.When, strings.Join(cv.Values, ", ")),
+					"")
+				v.Severity = "warning"
+				v.usage = &usage
+				v.prop = def.Name
+				v.value = value
+				if matches := suggestMatches(value, cv.Values); len(matches) > 0 {
+					v.Suggestion = didYouMean("%q", matches)
+					if len(matches) == 1 {
+						v.replacement = matches[0]
+					}
+				}
+				violations = append(violations, v)
+			}
+		}
+	}
+
+	return violations
+}
+
+// evalCondition reports whether a constraint's condition holds for usage.
+func evalCondition(usage JSXUsage, c catalog.PropCondition) truth {
+	if c.Children != "" {
+		return evalChildren(usage, c.Children)
+	}
+
+	if _, set := usage.Props[c.Prop]; !set {
+		if _, spread := usage.Props["...spread"]; spread {
+			return unknown
+		}
+		return fails
+	}
+	if len(c.Values) == 0 {
+		return holds
+	}
+	values := knownValues(usage, c.Prop)
+	if len(values) == 0 {
+		return unknown
+	}
+	matched := 0
+	for _, value := range values {
+		if containsName(c.Values, value) {
+			matched++
+		}
+	}
+	switch matched {
+	case len(values):
+		return holds
+	case 0:
+		return fails
+	}
+	return unknown
+}
+
+// evalChildren reports whether usage's children have the given shape.
+// Expression children may render anything, so they leave the answer open.
+func evalChildren(usage JSXUsage, shape string) truth {
+	switch shape {
+	case catalog.ChildrenEmpty:
+		switch {
+		case !usage.HasChildren:
+			return holds
+		case usage.HasText || usage.ElementChildren > 0:
+			return fails
+		}
+	case catalog.ChildrenSingleElement:
+		switch {
+		case !usage.HasChildren || usage.HasText || usage.ElementChildren > 1:
+			return fails
+		case usage.ElementChildren == 1:
+			return holds
+		}
+	}
+	return unknown
+}
+
+// knownValues returns the literal values a prop can have: its literal value,
+// or every value an expression like {cond ? "a" : "b"} can produce.
+func knownValues(usage JSXUsage, prop string) []string {
+	if values := usage.PropValues[prop]; len(values) > 0 {
+		return values
+	}
+	if value := usage.Props[prop]; value != "" {
+		return []string{value}
+	}
+	return nil
+}
+
+// requirement describes what a requires condition asks for.
+func requirement(c catalog.PropCondition) string {
+	switch {
+	case c.Children == catalog.ChildrenEmpty:
+		return "no children"
+	case c.Children == catalog.ChildrenSingleElement:
+		return "exactly one element child"
+	case len(c.Values) == 1:
+		return fmt.Sprintf("%s=%q", c.Prop, c.Values[0])
+	case len(c.Values) > 1:
+		return fmt.Sprintf("%s set to one of %s", c.Prop, strings.Join(c.Values, ", "))
+	default:
+		return c.Prop
+	}
+}
+
+// requirementSuggestion says how to meet a requires condition.
+func requirementSuggestion(prop string, c catalog.PropCondition) string {
+	switch {
+	case c.Children == catalog.ChildrenEmpty:
+		return fmt.Sprintf("Remove the children or remove %s", prop)
+	case c.Children == catalog.ChildrenSingleElement:
+		return "Pass a single element as the only child"
+	case len(c.Values) > 0:
+		return fmt.Sprintf("Set %s or remove %s", requirement(c), prop)
+	default:
+		return fmt.Sprintf("Add %s or remove %s", c.Prop, prop)
+	}
+}
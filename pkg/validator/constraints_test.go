@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnana997/uispec/pkg/catalog"
+)
+
+// constraintProps mirrors the constraints shadcn/ui components declare.
+var constraintProps = []catalog.Prop{
+	{Name: "type", Type: "string", AllowedValues: []string{"single", "multiple"}},
+	{Name: "collapsible", Type: "boolean", ConflictsWith: []catalog.PropCondition{{Prop: "type", Values: []string{"multiple"}}}},
+	{Name: "value", Type: "string", Requires: []catalog.PropCondition{{Prop: "onValueChange"}}},
+	{Name: "onValueChange", Type: "function"},
+	{Name: "asChild", Type: "boolean", Requires: []catalog.PropCondition{{Children: catalog.ChildrenSingleElement}}},
+	{Name: "label", Type: "string", Default: "Close", RequiredWhen: []catalog.PropCondition{{Children: catalog.ChildrenEmpty}}},
+	{Name: "href", Type: "string", ConflictsWith: []catalog.PropCondition{{Prop: "onClick"}}},
+	{Name: "onClick", Type: "function", ConflictsWith: []catalog.PropCondition{{Prop: "href"}}},
+	{Name: "size", Type: "string", AllowedValues: []string{"sm", "md", "lg"}, AllowedValuesWhen: []catalog.ConditionalValues{
+		{When: catalog.PropCondition{Prop: "type", Values: []string{"multiple"}}, Values: []string{"sm", "md"}},
+	}},
+}
+
+func TestCheckConstraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		usage    JSXUsage
+		expected []string // messages
+	}{
+		{
+			name:  "satisfied",
+			usage: JSXUsage{Props: map[string]string{"value": "a", "onValueChange": "", "label": "x"}, HasChildren: true, HasText: true},
+		},
+		{
+			name:     "requires prop",
+			usage:    JSXUsage{Props: map[string]string{"value": "a"}, HasChildren: true, HasText: true},
+			expected: []string{`Prop "value" on "Accordion" requires onValueChange`},
+		},
+		{
+			name:     "requires single element child",
+			usage:    JSXUsage{Props: map[string]string{"asChild": ""}, HasChildren: true, ElementChildren: 2},
+			expected: []string{`Prop "asChild" on "Accordion" requires exactly one element child`},
+		},
+		{
+			name:  "single element child",
+			usage: JSXUsage{Props: map[string]string{"asChild": ""}, HasChildren: true, ElementChildren: 1},
+		},
+		{
+			name:     "conflicts with value",
+			usage:    JSXUsage{Props: map[string]string{"type": "multiple", "collapsible": ""}, HasChildren: true, HasText: true},
+			expected: []string{`Prop "collapsible" on "Accordion" cannot be used when type is "multiple"`},
+		},
+		{
+			name:  "other value does not conflict",
+			usage: JSXUsage{Props: map[string]string{"type": "single", "collapsible": ""}, HasChildren: true, HasText: true},
+		},
+		{
+			name:     "mutual conflict is reported once",
+			usage:    JSXUsage{Props: map[string]string{"href": "/", "onClick": ""}, HasChildren: true, HasText: true},
+			expected: []string{`Prop "href" on "Accordion" cannot be used when onClick is set`},
+		},
+		{
+			name:     "required when empty",
+			usage:    JSXUsage{Props: map[string]string{}},
+			expected: []string{`Component "Accordion" is missing prop "label", required when it has no children`},
+		},
+		{
+			name:     "allowed values when",
+			usage:    JSXUsage{Props: map[string]string{"type": "multiple", "size": "lg"}, HasChildren: true, HasText: true},
+			expected: []string{`Prop "size" on "Accordion" has invalid value "lg" when type is "multiple" (allowed: sm, md)`},
+		},
+		{
+			name:  "spread leaves conditions open",
+			usage: JSXUsage{Props: map[string]string{"value": "a", "...spread": ""}},
+		},
+		{
+			name:  "expression children leave shape open",
+			usage: JSXUsage{Props: map[string]string{"asChild": ""}, HasChildren: true},
+		},
+		{
+			name:  "dynamic value leaves conflict open",
+			usage: JSXUsage{Props: map[string]string{"type": "", "collapsible": ""}, HasChildren: true, HasText: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.usage.ComponentName = "Accordion"
+			var messages []string
+			for _, v := range checkConstraints(tt.usage, constraintProps) {
+				messages = append(messages, v.Message)
+			}
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
+
+func TestCheckConstraints_Fixable(t *testing.T) {
+	usage := JSXUsage{ComponentName: "Accordion", Props: map[string]string{"type": "multiple", "size": "lgg"}}
+
+	violations := checkConstraints(usage, []catalog.Prop{
+		constraintProps[0],
+		constraintProps[5],
+		{Name: "size", Type: "string", AllowedValuesWhen: []catalog.ConditionalValues{
+			{When: catalog.PropCondition{Prop: "type", Values: []string{"multiple"}}, Values: []string{"sm", "lg"}},
+		}},
+	})
+
+	require.Len(t, violations, 2)
+	assert.Equal(t, "missing-required-prop", violations[0].Rule)
+	assert.Equal(t, `Add label="Close"`, violations[0].Suggestion)
+	assert.Equal(t, "label", violations[0].prop)
+	assert.Equal(t, "invalid-prop-value", violations[1].Rule)
+	assert.Equal(t, "warning", violations[1].Severity)
+	assert.Equal(t, "lg", violations[1].replacement)
+}
+
+func TestEvalCondition(t *testing.T) {
+	usage := JSXUsage{
+		Props:      map[string]string{"type": "", "size": "sm"},
+		PropValues: map[string][]string{"type": {"single", "multiple"}},
+	}
+	multiple := catalog.PropCondition{Prop: "type", Values: []string{"multiple"}}
+
+	assert.Equal(t, holds, evalCondition(usage, catalog.PropCondition{Prop: "size"}))
+	assert.Equal(t, fails, evalCondition(usage, catalog.PropCondition{Prop: "href"}))
+	assert.Equal(t, unknown, evalCondition(usage, multiple), "only one branch matches")
+	assert.Equal(t, holds, evalCondition(usage, catalog.PropCondition{Prop: "type", Values: []string{"single", "multiple"}}))
+	assert.Equal(t, fails, evalCondition(usage, catalog.PropCondition{Prop: "size", Values: []string{"lg"}}))
+	assert.Equal(t, holds, evalCondition(usage, catalog.PropCondition{Children: catalog.ChildrenEmpty}))
+	assert.Equal(t, fails, evalCondition(usage, catalog.PropCondition{Children: catalog.ChildrenSingleElement}))
+}
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+
+	"github.com/gnana997/uispec/pkg/parser"
+)
+
+// grammar is the tree-sitter grammar a page is parsed with.
+type grammar struct {
+	lang parser.Language
+	tsx  bool // TypeScript with JSX enabled
+}
+
+// tsxGrammar is used when neither a language nor a recognised filename is given.
+var tsxGrammar = grammar{lang: parser.LanguageTypeScript, tsx: true}
+
+// sourceLanguages are the extensions grammarForExt recognises.
+var sourceLanguages = []string{"tsx", "jsx", "ts", "js", "mts", "mjs", "cts", "cjs"}
+
+// SourceLanguages returns the source file extensions, without the dot, that
+// pages can be parsed as.
+func SourceLanguages() []string {
+	return slices.Clone(sourceLanguages)
+}
+
+// Languages returns the language hints ValidLanguage accepts besides the
+// empty one: the source extensions, then "md" and "mdx" for documents.
+func Languages() []string {
+	return append(SourceLanguages(), "md", "mdx")
+}
+
+// String names the grammar in parse error messages.
+func (g grammar) String() string {
+	switch {
+	case g.tsx:
+		return "TSX"
+	case g.lang == parser.LanguageJavaScript:
+		return "JavaScript"
+	default:
+		return "TypeScript"
+	}
+}
+
+// pageGrammar picks the grammar for a page. An explicit language hint, a
+// file extension such as "jsx" or ".ts", wins; otherwise the filename's
+// extension decides. Pages with no usable hint are parsed as TSX.
+func pageGrammar(language, filename string) (grammar, error) {
+	if language != "" {
+		g, ok := grammarForExt("." + strings.TrimPrefix(language, "."))
+		if !ok {
+			return grammar{}, fmt.Errorf("unsupported language %q", language)
+		}
+		return g, nil
+	}
+	if g, ok := grammarForExt(filename); ok {
+		return g, nil
+	}
+	return tsxGrammar, nil
+}
+
+// grammarForExt detects the grammar from a path's extension. JavaScript
+// always allows JSX; plain .ts files are parsed without it, as tsc does.
+func grammarForExt(path string) (grammar, bool) {
+	lang := parser.DetectLanguage(path)
+	if lang == parser.LanguageUnknown {
+		return grammar{}, false
+	}
+	return grammar{lang: lang, tsx: parser.IsTSXFile(path)}, true
+}
+
+// ValidLanguage reports whether language is a hint the validator accepts
+// for ValidateOptions.Language: empty, a source extension such as "tsx",
+// "jsx", "ts", "js" or "mts", or "md"/"mdx", with or without the leading dot.
+func ValidLanguage(language string) bool {
+	if doc, _ := documentKind(language, ""); doc {
+		return true
+	}
+	_, err := pageGrammar(language, "")
+	return err == nil
+}
+
+// parse parses source with the grammar g. The tree must be closed by the caller.
+func (v *Validator) parse(source []byte, g grammar) (*ts.Tree, error) {
+	return v.parser.Parse(source, g.lang, g.tsx)
+}
@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnana997/uispec/pkg/parser"
+)
+
+func TestPageGrammar(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		filename string
+		want     grammar
+	}{
+		{"default", "", "", tsxGrammar},
+		{"tsx file", "", "app/page.tsx", tsxGrammar},
+		{"jsx file", "", "src/Card.jsx", grammar{lang: parser.LanguageJavaScript}},
+		{"js file", "", "src/App.js", grammar{lang: parser.LanguageJavaScript}},
+		{"ts file", "", "src/util.ts", grammar{lang: parser.LanguageTypeScript}},
+		{"mts file", "", "src/util.mts", grammar{lang: parser.LanguageTypeScript}},
+		{"unknown extension", "", "README", tsxGrammar},
+		{"hint", "jsx", "", grammar{lang: parser.LanguageJavaScript}},
+		{"hint with dot", ".tsx", "", tsxGrammar},
+		{"hint beats filename", "js", "page.tsx", grammar{lang: parser.LanguageJavaScript}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pageGrammar(tt.language, tt.filename)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPageGrammar_UnsupportedLanguage(t *testing.T) {
+	_, err := pageGrammar("python", "page.tsx")
+	assert.EqualError(t, err, `unsupported language "python"`)
+
+	assert.False(t, ValidLanguage("vue"))
+	assert.True(t, ValidLanguage("jsx"))
+	assert.True(t, ValidLanguage(""))
+}
+
+func TestSourceLanguages(t *testing.T) {
+	for _, lang := range SourceLanguages() {
+		_, ok := grammarForExt("page." + lang)
+		assert.True(t, ok, lang)
+		assert.True(t, ValidLanguage(lang), lang)
+	}
+}
+
+func TestGrammarString(t *testing.T) {
+	assert.Equal(t, "TSX", tsxGrammar.String())
+	assert.Equal(t, "JavaScript", grammar{lang: parser.LanguageJavaScript}.String())
+	assert.Equal(t, "TypeScript", grammar{lang: parser.LanguageTypeScript}.String())
+}
@@ -0,0 +1,362 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// neededImport is an import a fix group relies on. A plain need adds name
+// from path; a need with from moves the binding local out of that statement
+// and into path.
+type neededImport struct {
+	name, path, rule string
+
+	local string      // binding to move
+	from  *ImportInfo // statement it is imported by
+
+	violation int // index of the violation that needs it
+}
+
+// importStyle is how a file writes its imports, so that fixes match it.
+type importStyle struct {
+	quote  byte // '"' or '\''
+	semi   bool // statements end with ";"
+	spaced bool // "{ A }" rather than "{A}"
+}
+
+// detectImportStyle reads the import style from the first import statement.
+// Without parsed imports it falls back to the first import line, and with no
+// imports at all to double quotes, spaced braces, and semicolons if other
+// statements use them.
+func detectImportStyle(code string, imports []ImportInfo) importStyle {
+	style := importStyle{quote: '"', spaced: true}
+	if len(imports) > 0 {
+		first := imports[0]
+		if !first.sourceSpan.empty() {
+			style.quote = code[first.sourceSpan.start]
+		}
+		style.semi = code[first.span.end-1] == ';'
+		for _, imp := range imports {
+			if named := code[imp.namedSpan.start:imp.namedSpan.end]; len(named) > 2 {
+				style.spaced = named[1] == ' ' || named[1] == '\n'
+				break
+			}
+		}
+		return style
+	}
+
+	for _, line := range strings.Split(code, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "import ") || strings.HasPrefix(trimmed, "import{") {
+			if q := strings.IndexAny(trimmed, `"'`); q >= 0 {
+				style.quote = trimmed[q]
+			}
+			style.semi = strings.HasSuffix(trimmed, ";")
+			style.spaced = !strings.Contains(trimmed, "{") || strings.Contains(trimmed, "{ ")
+			return style
+		}
+		if strings.HasSuffix(trimmed, ";") {
+			style.semi = true
+		}
+	}
+	return style
+}
+
+// importEdit collects the changes to one existing import statement.
+type importEdit struct {
+	imp     *ImportInfo
+	removed map[string]bool // local names moved to another statement
+	added   []string        // specifiers merged in
+	source  string          // new module path when the whole statement moves
+	need    neededImport    // first need behind the edit, for the fix metadata
+}
+
+// planImportFixes turns the needed imports into edits: names are merged
+// into an existing statement from the same module when there is one, a
+// statement whose bindings all move has its path rewritten (or is merged
+// into the target and removed), and one where only some do is split. New
+// statements go at insertAt.
+func planImportFixes(code string, imports []ImportInfo, needs []neededImport, insertAt int) []AutoFix {
+	style := detectImportStyle(code, imports)
+
+	edits := make(map[int]*importEdit) // statement start → edit
+	editFor := func(imp *ImportInfo, need neededImport) *importEdit {
+		e, ok := edits[imp.span.start]
+		if !ok {
+			e = &importEdit{imp: imp, removed: make(map[string]bool), need: need}
+			edits[imp.span.start] = e
+		}
+		return e
+	}
+	mergeTarget := func(path string) *ImportInfo {
+		for i := range imports {
+			imp := &imports[i]
+			if imp.Source != path || imp.typeOnly || imp.Namespace != "" {
+				continue
+			}
+			if e, ok := edits[imp.span.start]; ok && e.source != "" {
+				continue
+			}
+			return imp
+		}
+		return nil
+	}
+
+	type pendingAdd struct {
+		need neededImport
+		spec string
+	}
+	var adds []pendingAdd
+
+	// Moves first, so merge targets know which statements are changing.
+	moves := make(map[int][]neededImport)
+	var movedFrom []*ImportInfo
+	for _, need := range needs {
+		if need.from == nil {
+			adds = append(adds, pendingAdd{need: need, spec: need.name})
+			continue
+		}
+		start := need.from.span.start
+		if _, ok := moves[start]; !ok {
+			movedFrom = append(movedFrom, need.from)
+		}
+		moves[start] = append(moves[start], need)
+	}
+	for _, imp := range movedFrom {
+		stmtMoves := moves[imp.span.start]
+		target := stmtMoves[0].path
+		moving := make(map[string]bool)
+		samePath := true
+		for _, m := range stmtMoves {
+			moving[m.local] = true
+			samePath = samePath && m.path == target
+		}
+
+		all := samePath
+		for _, local := range importLocals(imp) {
+			all = all && moving[local]
+		}
+
+		switch {
+		case all && (imp.DefaultName != "" || imp.Namespace != "" || imp.typeOnly || mergeTarget(target) == nil):
+			editFor(imp, stmtMoves[0]).source = target
+		default:
+			// Split out the named specifiers; default and namespace
+			// bindings can't be split.
+			for _, m := range stmtMoves {
+				spec, ok := specifierText(code, imp, m.local)
+				if !ok || imp.typeOnly {
+					continue
+				}
+				editFor(imp, m).removed[m.local] = true
+				adds = append(adds, pendingAdd{need: m, spec: spec})
+			}
+		}
+	}
+
+	var newPaths []string
+	newSpecs := make(map[string][]string)
+	newNeeds := make(map[string]neededImport)
+	for _, add := range adds {
+		local := specifierLocal(add.spec)
+		if alreadyImported(imports, edits, add.need.path, local) {
+			continue
+		}
+		if target := mergeTarget(add.need.path); target != nil {
+			e := editFor(target, add.need)
+			if !containsName(e.added, add.spec) {
+				e.added = append(e.added, add.spec)
+			}
+			continue
+		}
+		if _, ok := newSpecs[add.need.path]; !ok {
+			newPaths = append(newPaths, add.need.path)
+			newNeeds[add.need.path] = add.need
+		}
+		if !containsName(newSpecs[add.need.path], add.spec) {
+			newSpecs[add.need.path] = append(newSpecs[add.need.path], add.spec)
+		}
+	}
+
+	starts := make([]int, 0, len(edits))
+	for start := range edits {
+		starts = append(starts, start)
+	}
+	sort.Ints(starts)
+
+	var fixes []AutoFix
+	for _, start := range starts {
+		for _, fix := range renderImportEdit(code, edits[start], style) {
+			fix.Violation = edits[start].need.violation
+			fixes = append(fixes, fix)
+		}
+	}
+
+	prefix := ""
+	if insertAt == len(code) && insertAt > 0 && code[insertAt-1] != '\n' {
+		prefix = "\n"
+	}
+	for _, path := range newPaths {
+		need := newNeeds[path]
+		text := prefix + "import " + renderNamedImports(code, nil, newSpecs[path], style) + " from " + quote(path, style) + semicolon(style) + "\n"
+		fix := newFix(code, span{start: insertAt, end: insertAt}, text, need.rule, "Add missing import", need.name)
+		fix.Violation = need.violation
+		fixes = append(fixes, fix)
+		prefix = ""
+	}
+	return fixes
+}
+
+// renderImportEdit returns the fixes for one import statement edit.
+func renderImportEdit(code string, e *importEdit, style importStyle) []AutoFix {
+	imp := e.imp
+	name := e.need.name
+
+	if e.source != "" {
+		path := span{start: imp.sourceSpan.start + 1, end: imp.sourceSpan.end - 1}
+		if path.empty() || code[path.start:path.end] == e.source {
+			return nil
+		}
+		return []AutoFix{newFix(code, path, e.source, e.need.rule, fmt.Sprintf("Fix import path for %s", name), name)}
+	}
+
+	var specs []string
+	for _, spec := range imp.specifiers {
+		if !e.removed[spec.local] {
+			specs = append(specs, code[spec.span.start:spec.span.end])
+		}
+	}
+	specs = append(specs, e.added...)
+
+	reason := fmt.Sprintf("Add %s to import from %q", name, imp.Source)
+	if len(e.removed) > 0 {
+		reason = fmt.Sprintf("Move %s to import from %q", name, e.need.path)
+	}
+
+	if len(specs) == 0 && imp.DefaultName == "" {
+		// Nothing is left: remove the statement and its line break.
+		s := imp.span
+		if strings.HasPrefix(code[s.end:], "\n") && (s.start == 0 || code[s.start-1] == '\n') {
+			s.end++
+		}
+		return []AutoFix{newFix(code, s, "", e.need.rule, reason, name)}
+	}
+
+	clause := imp.DefaultName
+	if len(specs) > 0 {
+		if clause != "" {
+			clause += ", "
+		}
+		clause += renderNamedImports(code, imp, specs, style)
+	}
+	if imp.clauseSpan.empty() || code[imp.clauseSpan.start:imp.clauseSpan.end] == clause {
+		return nil
+	}
+	return []AutoFix{newFix(code, imp.clauseSpan, clause, e.need.rule, reason, name)}
+}
+
+// renderNamedImports renders { A, B } in the layout of imp's named imports,
+// keeping a multi-line list multi-line, or in style for a new list.
+func renderNamedImports(code string, imp *ImportInfo, specs []string, style importStyle) string {
+	if imp == nil || imp.namedSpan.empty() {
+		if style.spaced {
+			return "{ " + strings.Join(specs, ", ") + " }"
+		}
+		return "{" + strings.Join(specs, ", ") + "}"
+	}
+
+	named := code[imp.namedSpan.start:imp.namedSpan.end]
+	if !strings.Contains(named, "\n") || len(imp.specifiers) == 0 {
+		if len(named) > 2 && named[1] != ' ' {
+			return "{" + strings.Join(specs, ", ") + "}"
+		}
+		return "{ " + strings.Join(specs, ", ") + " }"
+	}
+
+	indent := lineIndent(code, imp.specifiers[0].span.start)
+	closing := lineIndent(code, imp.namedSpan.end-1)
+	trailing := ""
+	if strings.HasSuffix(strings.TrimSpace(named[:len(named)-1]), ",") {
+		trailing = ","
+	}
+	return "{\n" + indent + strings.Join(specs, ",\n"+indent) + trailing + "\n" + closing + "}"
+}
+
+// lineIndent returns the whitespace before offset on its line, or "" if
+// offset is not the first non-blank character of the line.
+func lineIndent(code string, offset int) string {
+	start := strings.LastIndexByte(code[:offset], '\n') + 1
+	indent := code[start:offset]
+	if strings.TrimLeft(indent, " \t") != "" {
+		return ""
+	}
+	return indent
+}
+
+// importLocals returns every local name an import statement binds.
+func importLocals(imp *ImportInfo) []string {
+	var locals []string
+	for _, spec := range imp.specifiers {
+		locals = append(locals, spec.local)
+	}
+	if imp.DefaultName != "" {
+		locals = append(locals, imp.DefaultName)
+	}
+	if imp.Namespace != "" {
+		locals = append(locals, imp.Namespace)
+	}
+	return locals
+}
+
+// specifierText returns the source of the named import binding local.
+func specifierText(code string, imp *ImportInfo, local string) (string, bool) {
+	for _, spec := range imp.specifiers {
+		if spec.local == local {
+			return code[spec.span.start:spec.span.end], true
+		}
+	}
+	return "", false
+}
+
+// specifierLocal returns the local name a specifier binds, e.g. "Btn" for
+// "Button as Btn".
+func specifierLocal(spec string) string {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+// alreadyImported reports whether a statement from path already binds local
+// and keeps it.
+func alreadyImported(imports []ImportInfo, edits map[int]*importEdit, path, local string) bool {
+	for i := range imports {
+		imp := &imports[i]
+		if imp.Source != path {
+			continue
+		}
+		if e, ok := edits[imp.span.start]; ok && (e.removed[local] || e.source != "") {
+			continue
+		}
+		if containsName(importLocals(imp), local) {
+			return true
+		}
+	}
+	return false
+}
+
+// quote quotes a module path in the file's quote style.
+func quote(path string, style importStyle) string {
+	q := string(style.quote)
+	return q + path + q
+}
+
+// semicolon returns the statement terminator for the file's style.
+func semicolon(style importStyle) string {
+	if style.semi {
+		return ";"
+	}
+	return ""
+}
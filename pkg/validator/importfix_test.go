@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testImport builds the ImportInfo for the import statement stmt in code,
+// locating its parts textually.
+func testImport(code, stmt string) ImportInfo {
+	s := spanOf(code, stmt)
+	imp := ImportInfo{span: s, Line: 1 + strings.Count(code[:s.start], "\n")}
+
+	from := strings.LastIndex(stmt, " from ")
+	q := s.start + from + len(" from ")
+	end := q + 1 + strings.IndexByte(code[q+1:], code[q])
+	imp.sourceSpan = span{start: q, end: end + 1}
+	imp.Source = code[q+1 : end]
+
+	clauseStart := s.start + len("import ")
+	if strings.HasPrefix(stmt, "import type ") {
+		imp.typeOnly = true
+		clauseStart += len("type ")
+	}
+	imp.clauseSpan = span{start: clauseStart, end: s.start + from}
+	clause := code[imp.clauseSpan.start:imp.clauseSpan.end]
+
+	if open := strings.IndexByte(clause, '{'); open >= 0 {
+		closing := strings.IndexByte(clause, '}')
+		imp.namedSpan = span{start: clauseStart + open, end: clauseStart + closing + 1}
+		offset := clauseStart + open + 1
+		for _, part := range strings.Split(clause[open+1:closing], ",") {
+			spec := strings.TrimSpace(part)
+			if spec != "" {
+				start := offset + strings.Index(part, spec)
+				imp.specifiers = append(imp.specifiers, importSpecifier{local: specifierLocal(spec), span: span{start: start, end: start + len(spec)}})
+				imp.Names = append(imp.Names, strings.Fields(spec)[0])
+			}
+			offset += len(part) + 1
+		}
+		clause = clause[:open]
+	}
+	if name := strings.Trim(clause, " ,"); strings.HasPrefix(name, "* as ") {
+		imp.Namespace = strings.TrimPrefix(name, "* as ")
+	} else {
+		imp.DefaultName = name
+	}
+	return imp
+}
+
+func TestPlanImportFixes(t *testing.T) {
+	tests := []struct {
+		name  string
+		code  string
+		stmts []string
+		needs func(imports []ImportInfo) []neededImport
+		want  string
+	}{
+		{
+			name:  "merge into existing statement",
+			code:  "import { Card } from \"ui/card\";\n\n<Card />",
+			stmts: []string{`import { Card } from "ui/card";`},
+			needs: func([]ImportInfo) []neededImport {
+				return []neededImport{{name: "CardHeader", path: "ui/card"}, {name: "CardTitle", path: "ui/card"}}
+			},
+			want: "import { Card, CardHeader, CardTitle } from \"ui/card\";\n\n<Card />",
+		},
+		{
+			name:  "merge after default import",
+			code:  "import Card from 'ui/card'\n<Card />",
+			stmts: []string{`import Card from 'ui/card'`},
+			needs: func([]ImportInfo) []neededImport {
+				return []neededImport{{name: "CardHeader", path: "ui/card"}}
+			},
+			want: "import Card, { CardHeader } from 'ui/card'\n<Card />",
+		},
+		{
+			name:  "new statement in file style",
+			code:  "import {React} from 'react';\n\n<Button />",
+			stmts: []string{`import {React} from 'react';`},
+			needs: func([]ImportInfo) []neededImport {
+				return []neededImport{{name: "Button", path: "ui/button"}, {name: "Badge", path: "ui/badge"}}
+			},
+			want: "import {React} from 'react';\nimport {Button} from 'ui/button';\nimport {Badge} from 'ui/badge';\n\n<Button />",
+		},
+		{
+			name:  "multi-line statement stays multi-line",
+			code:  "import {\n  Card,\n  CardHeader,\n} from \"ui/card\"\n",
+			stmts: []string{"import {\n  Card,\n  CardHeader,\n} from \"ui/card\""},
+			needs: func([]ImportInfo) []neededImport {
+				return []neededImport{{name: "CardTitle", path: "ui/card"}}
+			},
+			want: "import {\n  Card,\n  CardHeader,\n  CardTitle,\n} from \"ui/card\"\n",
+		},
+		{
+			name:  "already imported",
+			code:  "import { Card } from \"ui/card\"\n",
+			stmts: []string{`import { Card } from "ui/card"`},
+			needs: func([]ImportInfo) []neededImport {
+				return []neededImport{{name: "Card", path: "ui/card"}}
+			},
+			want: "import { Card } from \"ui/card\"\n",
+		},
+		{
+			name:  "rewrite path of whole statement",
+			code:  "import { Button as Btn } from \"wrong\"\n",
+			stmts: []string{`import { Button as Btn } from "wrong"`},
+			needs: func(imports []ImportInfo) []neededImport {
+				return []neededImport{{name: "Button", path: "ui/button", local: "Btn", from: &imports[0]}}
+			},
+			want: "import { Button as Btn } from \"ui/button\"\n",
+		},
+		{
+			name:  "split mis-sourced name",
+			code:  "import { Button, Badge as B } from \"ui/button\"\n\n<B />",
+			stmts: []string{`import { Button, Badge as B } from "ui/button"`},
+			needs: func(imports []ImportInfo) []neededImport {
+				return []neededImport{{name: "Badge", path: "ui/badge", local: "B", from: &imports[0]}}
+			},
+			want: "import { Button } from \"ui/button\"\nimport { Badge as B } from \"ui/badge\"\n\n<B />",
+		},
+		{
+			name:  "move into existing statement",
+			code:  "import { Card } from \"ui/card\"\nimport { CardHeader } from \"wrong\"\n\n<Card />",
+			stmts: []string{`import { Card } from "ui/card"`, `import { CardHeader } from "wrong"`},
+			needs: func(imports []ImportInfo) []neededImport {
+				return []neededImport{{name: "CardHeader", path: "ui/card", local: "CardHeader", from: &imports[1]}}
+			},
+			want: "import { Card, CardHeader } from \"ui/card\"\n\n<Card />",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var imports []ImportInfo
+			for _, stmt := range tt.stmts {
+				imports = append(imports, testImport(tt.code, stmt))
+			}
+
+			fixes := planImportFixes(tt.code, imports, tt.needs(imports), importsEnd(tt.code, imports))
+			sortFixes(fixes)
+
+			assert.Equal(t, tt.want, applyFixes(tt.code, fixes))
+		})
+	}
+}
+
+func TestDetectImportStyle(t *testing.T) {
+	assert.Equal(t, importStyle{quote: '"', spaced: true}, detectImportStyle("<div />", nil))
+	assert.Equal(t, importStyle{quote: '"', semi: true, spaced: true}, detectImportStyle("const a = 1;\n<div />", nil))
+	assert.Equal(t, importStyle{quote: '\'', semi: true}, detectImportStyle("import {a} from 'a';\n", nil))
+
+	code := "import { a } from 'a'\n"
+	style := detectImportStyle(code, []ImportInfo{testImport(code, `import { a } from 'a'`)})
+	require.Equal(t, byte('\''), style.quote)
+	assert.False(t, style.semi)
+	assert.True(t, style.spaced)
+}
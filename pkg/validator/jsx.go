@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"strings"
 	"unicode"
 
 	ts "github.com/tree-sitter/go-tree-sitter"
@@ -8,32 +9,89 @@ import (
 
 // JSXUsage represents a single JSX component usage in the code.
 type JSXUsage struct {
-	ComponentName   string            `json:"component_name"`
-	Props           map[string]string `json:"props"`            // prop name → literal value ("" for expressions)
-	HasChildren     bool              `json:"has_children"`
-	ParentComponent string            `json:"parent_component"` // nearest ancestor component ("" if none)
-	Line            int               `json:"line"`             // 1-based
-	Column          int               `json:"column"`           // 1-based
+	ComponentName   string              `json:"component_name"`
+	Props           map[string]string   `json:"props"` // prop name → literal value ("" for expressions)
+	HasChildren     bool                `json:"has_children"`
+	HasText         bool                `json:"has_text,omitempty"`         // direct text children, e.g. <Input>label</Input>
+	ElementChildren int                 `json:"element_children,omitempty"` // direct element children, not counting expressions
+	ParentComponent string              `json:"parent_component"`           // nearest ancestor component ("" if none)
+	Ancestors       []string            `json:"ancestors,omitempty"`        // enclosing components, outermost first
+	Line            int                 `json:"line"`                       // 1-based
+	Column          int                 `json:"column"`                     // 1-based
+	CloseLine       int                 `json:"close_line,omitempty"`       // 1-based line of the closing tag (0 if self-closing)
+	LocalName       string              `json:"local_name,omitempty"`       // tag as written when it differs from ComponentName (e.g. "Btn", "UI.Button")
+	PropKinds       map[string]string   `json:"prop_kinds,omitempty"`       // prop name → value kind (see ValueKind* constants)
+	PropValues      map[string][]string `json:"prop_values,omitempty"`      // prop name → possible literal values of an expression
+
+	// Source ranges used to build fixes.
+	span         span                // the whole element
+	tagSpan      span                // tag name in the opening (or self-closing) tag
+	closeTagSpan span                // tag name in the closing tag; empty if self-closing
+	bodySpan     span                // between the opening and closing tags; empty if self-closing
+	attrs        map[string]attrSpan // prop name → attribute ranges
+}
+
+// span is a byte range [start, end) of the source.
+type span struct {
+	start, end int
+}
+
+// nodeSpan returns the byte range of a tree-sitter node.
+func nodeSpan(node *ts.Node) span {
+	return span{start: int(node.StartByte()), end: int(node.EndByte())}
+}
+
+// empty reports whether the span covers no source.
+func (s span) empty() bool {
+	return s.end <= s.start
+}
+
+// attrSpan holds the ranges of a JSX attribute's name and value. The value
+// includes its quotes or braces and is empty for boolean shorthand.
+type attrSpan struct {
+	name, value span
+	literals    []span // contents of the string literals written in the value
 }
 
 // ImportInfo represents an import statement extracted from the code.
 type ImportInfo struct {
-	Source      string   `json:"source"`
-	Names       []string `json:"names"`
-	DefaultName string   `json:"default_name,omitempty"`
-	Line        int      `json:"line"`
+	Source      string            `json:"source"`
+	Names       []string          `json:"names"` // exported names, before any "as" renaming
+	DefaultName string            `json:"default_name,omitempty"`
+	Aliases     map[string]string `json:"aliases,omitempty"`   // local name → exported name for "X as Y"
+	Namespace   string            `json:"namespace,omitempty"` // local name of "* as NS"
+	Line        int               `json:"line"`
+
+	span       span              // the whole import statement
+	sourceSpan span              // the module string, including its quotes
+	clauseSpan span              // the bindings between "import" and "from"
+	namedSpan  span              // the { ... } of named imports
+	specifiers []importSpecifier // named imports, in source order
+	typeOnly   bool              // import type { ... }
+}
+
+// importSpecifier is one entry of a named import, e.g. "Button as Btn".
+type importSpecifier struct {
+	local string
+	span  span
 }
 
 // JSXExtraction holds all extracted JSX usages and imports from a code string.
 type JSXExtraction struct {
-	Usages  []JSXUsage
-	Imports []ImportInfo
+	Usages     []JSXUsage
+	Imports    []ImportInfo
+	Intrinsics []JSXUsage // plain HTML elements such as <button> or <input>
+
+	consts   *constEvaluator
+	declared map[string]bool // names declared at the top level of the file
 }
 
 // ExtractJSX walks a tree-sitter AST and extracts JSX component usages and imports.
 func ExtractJSX(tree *ts.Tree, source []byte) *JSXExtraction {
 	result := &JSXExtraction{}
 	root := tree.RootNode()
+	result.consts = newConstEvaluator(root, source)
+	result.declared = collectDeclarations(root, source)
 
 	// Extract imports from top-level import_statement nodes.
 	extractImports(root, source, result)
@@ -42,9 +100,93 @@ func ExtractJSX(tree *ts.Tree, source []byte) *JSXExtraction {
 	var parentStack []string
 	walkJSX(root, source, &parentStack, result)
 
+	// Resolve aliased and namespaced tags to the components they import.
+	bindings := result.bindings()
+	for _, usages := range [][]JSXUsage{result.Usages, result.Intrinsics} {
+		for i := range usages {
+			usage := &usages[i]
+			if resolved := resolveTag(usage.ComponentName, bindings); resolved != usage.ComponentName {
+				usage.LocalName = usage.ComponentName
+				usage.ComponentName = resolved
+			}
+			usage.ParentComponent = resolveTag(usage.ParentComponent, bindings)
+			for j, ancestor := range usage.Ancestors {
+				usage.Ancestors[j] = resolveTag(ancestor, bindings)
+			}
+		}
+	}
+
+	return result
+}
+
+// importBinding is what a local identifier brought in by an import refers to.
+type importBinding struct {
+	source    string
+	exported  string // exported name; "default" for default imports
+	namespace bool   // bound by "* as NS"
+	imp       *ImportInfo
+}
+
+// bindings maps every local identifier bound by an import to its binding.
+func (e *JSXExtraction) bindings() map[string]importBinding {
+	result := make(map[string]importBinding)
+	for i := range e.Imports {
+		imp := &e.Imports[i]
+		aliased := make(map[string]bool, len(imp.Aliases))
+		for local, exported := range imp.Aliases {
+			result[local] = importBinding{source: imp.Source, exported: exported, imp: imp}
+			aliased[exported] = true
+		}
+		for _, name := range imp.Names {
+			if !aliased[name] {
+				result[name] = importBinding{source: imp.Source, exported: name, imp: imp}
+			}
+		}
+		if imp.DefaultName != "" {
+			result[imp.DefaultName] = importBinding{source: imp.Source, exported: "default", imp: imp}
+		}
+		if imp.Namespace != "" {
+			result[imp.Namespace] = importBinding{source: imp.Source, namespace: true, imp: imp}
+		}
+	}
 	return result
 }
 
+// resolveTag maps a tag as written to the component it refers to:
+// "Btn" → "Button" for an aliased import and "UI.Button" → "Button" for a
+// namespace import. Unbound tags are returned unchanged, and so are default
+// imports, which need the catalog (see Validator.resolveDefaultImports).
+func resolveTag(tag string, bindings map[string]importBinding) string {
+	root, member, hasMember := strings.Cut(tag, ".")
+	b, ok := bindings[root]
+	if !ok {
+		return tag
+	}
+	switch {
+	case b.namespace:
+		if hasMember {
+			return member
+		}
+		return tag
+	case b.exported == "default":
+		return tag
+	case hasMember:
+		return b.exported + "." + member
+	}
+	return b.exported
+}
+
+// bindingName returns the local identifier a usage's tag is bound through,
+// e.g. "UI" for <UI.Button> or "Btn" for <Btn>.
+func (u JSXUsage) bindingName() string {
+	name := u.ComponentName
+	if u.LocalName != "" {
+		name = u.LocalName
+	}
+	root, _, _ := strings.Cut(name, ".")
+	return root
+}
+
 // extractImports extracts import statements from the AST.
 func extractImports(node *ts.Node, source []byte, result *JSXExtraction) {
 	for i := uint(0); i < uint(node.ChildCount()); i++ {
@@ -55,6 +197,7 @@ func extractImports(node *ts.Node, source []byte, result *JSXExtraction) {
 
 		info := ImportInfo{
 			Line: int(child.StartPosition().Row) + 1,
+			span: nodeSpan(child),
 		}
 
 		for j := uint(0); j < uint(child.ChildCount()); j++ {
@@ -62,8 +205,12 @@ func extractImports(node *ts.Node, source []byte, result *JSXExtraction) {
 			switch part.Kind() {
 			case "string":
 				info.Source = extractStringContent(part, source)
+				info.sourceSpan = nodeSpan(part)
 			case "import_clause":
+				info.clauseSpan = nodeSpan(part)
 				extractImportClause(part, source, &info)
+			case "type":
+				info.typeOnly = true
 			}
 		}
 
@@ -83,22 +230,42 @@ func extractImportClause(node *ts.Node, source []byte, info *ImportInfo) {
 			info.DefaultName = child.Utf8Text(source)
 		case "named_imports":
 			extractNamedImports(child, source, info)
+		case "namespace_import":
+			// Namespace import: import * as UI from "..."
+			for j := uint(0); j < uint(child.ChildCount()); j++ {
+				if part := child.Child(j); part.Kind() == "identifier" {
+					info.Namespace = part.Utf8Text(source)
+				}
+			}
 		}
 	}
 }
 
 // extractNamedImports processes { Button, Dialog } in an import statement.
 func extractNamedImports(node *ts.Node, source []byte, info *ImportInfo) {
+	info.namedSpan = nodeSpan(node)
 	for i := uint(0); i < uint(node.ChildCount()); i++ {
 		child := node.Child(i)
 		if child.Kind() == "import_specifier" {
-			// The first identifier child is the imported name.
+			// The first identifier child is the imported name; a second one
+			// is the local alias in "Button as Btn".
+			var names []string
 			for j := uint(0); j < uint(child.ChildCount()); j++ {
 				spec := child.Child(j)
 				if spec.Kind() == "identifier" {
-					info.Names = append(info.Names, spec.Utf8Text(source))
-					break
+					names = append(names, spec.Utf8Text(source))
+				}
+			}
+			if len(names) == 0 {
+				continue
+			}
+			info.Names = append(info.Names, names[0])
+			info.specifiers = append(info.specifiers, importSpecifier{local: names[len(names)-1], span: nodeSpan(child)})
+			if len(names) > 1 && names[1] != names[0] {
+				if info.Aliases == nil {
+					info.Aliases = make(map[string]string)
 				}
+				info.Aliases[names[1]] = names[0]
 			}
 		}
 	}
@@ -120,6 +287,37 @@ func extractStringContent(node *ts.Node, source []byte) string {
 	return text
 }
 
+// collectDeclarations returns the names of the functions, classes, and
+// variables declared at the top level of the file, exported or not.
+func collectDeclarations(root *ts.Node, source []byte) map[string]bool {
+	declared := make(map[string]bool)
+	for i := uint(0); i < uint(root.ChildCount()); i++ {
+		stmt := root.Child(i)
+		if stmt.Kind() == "export_statement" {
+			if decl := stmt.ChildByFieldName("declaration"); decl != nil {
+				stmt = decl
+			}
+		}
+		switch stmt.Kind() {
+		case "function_declaration", "generator_function_declaration", "class_declaration":
+			if name := stmt.ChildByFieldName("name"); name != nil {
+				declared[name.Utf8Text(source)] = true
+			}
+		case "lexical_declaration", "variable_declaration":
+			for j := uint(0); j < uint(stmt.ChildCount()); j++ {
+				decl := stmt.Child(j)
+				if decl.Kind() != "variable_declarator" {
+					continue
+				}
+				if name := decl.ChildByFieldName("name"); name != nil && name.Kind() == "identifier" {
+					declared[name.Utf8Text(source)] = true
+				}
+			}
+		}
+	}
+	return declared
+}
+
 // walkJSX recursively walks the AST to extract JSX component usages.
 func walkJSX(node *ts.Node, source []byte, parentStack *[]string, result *JSXExtraction) {
 	kind := node.Kind()
@@ -141,33 +339,40 @@ func walkJSX(node *ts.Node, source []byte, parentStack *[]string, result *JSXExt
 
 // processJSXElement handles <Component ...>children</Component>.
 func processJSXElement(node *ts.Node, source []byte, parentStack *[]string, result *JSXExtraction) {
-	var tagName string
-	var props map[string]string
+	usage := JSXUsage{
+		HasChildren:     hasJSXChildren(node, source),
+		HasText:         hasJSXText(node, source),
+		ElementChildren: countJSXElements(node),
+		ParentComponent: currentParent(*parentStack),
+		Ancestors:       ancestors(*parentStack),
+		Line:            int(node.StartPosition().Row) + 1,
+		Column:          int(node.StartPosition().Column) + 1,
+		CloseLine:       int(node.EndPosition().Row) + 1,
+		span:            nodeSpan(node),
+	}
 
-	// Get tag name and props from jsx_opening_element.
+	// Get tag name and props from jsx_opening_element, and the closing tag
+	// name from jsx_closing_element.
 	for i := uint(0); i < uint(node.ChildCount()); i++ {
 		child := node.Child(i)
-		if child.Kind() == "jsx_opening_element" {
-			tagName, props = extractTagAndProps(child, source)
-			break
+		switch child.Kind() {
+		case "jsx_opening_element":
+			extractTagAndProps(child, source, result.consts, &usage)
+			usage.bodySpan.start = int(child.EndByte())
+		case "jsx_closing_element":
+			if name := child.ChildByFieldName("name"); name != nil {
+				usage.closeTagSpan = nodeSpan(name)
+			}
+			usage.bodySpan.end = int(child.StartByte())
 		}
 	}
 
-	// Count component children (not text, not HTML).
-	hasChildren := hasJSXChildren(node, source)
-
+	tagName := usage.ComponentName
 	isComponent := isComponentName(tagName)
-	parentComponent := currentParent(*parentStack)
-
 	if isComponent {
-		result.Usages = append(result.Usages, JSXUsage{
-			ComponentName:   tagName,
-			Props:           props,
-			HasChildren:     hasChildren,
-			ParentComponent: parentComponent,
-			Line:            int(node.StartPosition().Row) + 1,
-			Column:          int(node.StartPosition().Column) + 1,
-		})
+		result.Usages = append(result.Usages, usage)
+	} else if isIntrinsicName(tagName) {
+		result.Intrinsics = append(result.Intrinsics, usage)
 	}
 
 	// Push this component (or HTML tag) as parent for children.
@@ -191,24 +396,33 @@ func processJSXElement(node *ts.Node, source []byte, parentStack *[]string, resu
 
 // processJSXSelfClosing handles <Component ... />.
 func processJSXSelfClosing(node *ts.Node, source []byte, parentStack *[]string, result *JSXExtraction) {
-	tagName, props := extractTagAndProps(node, source)
+	usage := JSXUsage{
+		HasChildren:     false,
+		ParentComponent: currentParent(*parentStack),
+		Ancestors:       ancestors(*parentStack),
+		Line:            int(node.StartPosition().Row) + 1,
+		Column:          int(node.StartPosition().Column) + 1,
+		span:            nodeSpan(node),
+	}
+	extractTagAndProps(node, source, result.consts, &usage)
 
+	tagName := usage.ComponentName
 	if isComponentName(tagName) {
-		result.Usages = append(result.Usages, JSXUsage{
-			ComponentName:   tagName,
-			Props:           props,
-			HasChildren:     false,
-			ParentComponent: currentParent(*parentStack),
-			Line:            int(node.StartPosition().Row) + 1,
-			Column:          int(node.StartPosition().Column) + 1,
-		})
+		result.Usages = append(result.Usages, usage)
+	} else if isIntrinsicName(tagName) {
+		result.Intrinsics = append(result.Intrinsics, usage)
 	}
 }
 
-// extractTagAndProps gets the tag name and props from an opening element or self-closing element.
-func extractTagAndProps(node *ts.Node, source []byte) (string, map[string]string) {
+// extractTagAndProps fills in the tag name, props, prop value kinds, and the
+// possible values of expression props of usage from an opening element or
+// self-closing element.
+func extractTagAndProps(node *ts.Node, source []byte, consts *constEvaluator, usage *JSXUsage) {
 	var tagName string
 	props := make(map[string]string)
+	kinds := make(map[string]string)
+	attrs := make(map[string]attrSpan)
+	var values map[string][]string
 
 	for i := uint(0); i < uint(node.ChildCount()); i++ {
 		child := node.Child(i)
@@ -216,11 +430,33 @@ func extractTagAndProps(node *ts.Node, source []byte) (string, map[string]string
 		case "identifier", "member_expression", "nested_identifier":
 			if tagName == "" {
 				tagName = child.Utf8Text(source)
+				usage.tagSpan = nodeSpan(child)
 			}
 		case "jsx_attribute":
-			name, value := extractAttribute(child, source)
-			if name != "" {
-				props[name] = value
+			name, value, kind := extractAttribute(child, source)
+			if name == "" {
+				break
+			}
+			props[name] = value
+			kinds[name] = kind
+			attrs[name] = extractAttributeSpan(child)
+
+			// Resolve expressions like {"ghost"} or {cond ? "a" : "b"}.
+			if expr := attributeExpression(child); expr != nil && consts != nil {
+				possible, complete := consts.evaluate(expr)
+				if len(possible) == 0 {
+					break
+				}
+				if values == nil {
+					values = make(map[string][]string)
+				}
+				values[name] = possible
+				if complete {
+					kinds[name] = ValueKindString
+					if len(possible) == 1 {
+						props[name] = possible[0]
+					}
+				}
 			}
 		case "jsx_expression":
 			// Spread props: {...props}
@@ -232,12 +468,59 @@ func extractTagAndProps(node *ts.Node, source []byte) (string, map[string]string
 		}
 	}
 
-	return tagName, props
+	usage.ComponentName = tagName
+	usage.Props = props
+	usage.PropKinds = kinds
+	usage.PropValues = values
+	usage.attrs = attrs
+}
+
+// attributeExpression returns the jsx_expression value of a jsx_attribute, if any.
+func attributeExpression(node *ts.Node) *ts.Node {
+	for i := uint(0); i < uint(node.ChildCount()); i++ {
+		if child := node.Child(i); child.Kind() == "jsx_expression" {
+			return child
+		}
+	}
+	return nil
+}
+
+// extractAttributeSpan returns the ranges of a jsx_attribute's name and value,
+// and of the string literals in the value.
+func extractAttributeSpan(node *ts.Node) attrSpan {
+	var a attrSpan
+	for i := uint(0); i < uint(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch child.Kind() {
+		case "property_identifier":
+			a.name = nodeSpan(child)
+		case "string", "jsx_expression":
+			a.value = nodeSpan(child)
+			a.literals = stringLiterals(child)
+		case "jsx_element", "jsx_self_closing_element", "jsx_fragment":
+			a.value = nodeSpan(child)
+		}
+	}
+	return a
+}
+
+// stringLiterals returns the contents of the string literals in node, such
+// as both branches of {cond ? "a" : "b"}.
+func stringLiterals(node *ts.Node) []span {
+	if node.Kind() == "string" {
+		s := nodeSpan(node)
+		return []span{{start: s.start + 1, end: s.end - 1}}
+	}
+	var spans []span
+	for i := uint(0); i < uint(node.ChildCount()); i++ {
+		spans = append(spans, stringLiterals(node.Child(i))...)
+	}
+	return spans
 }
 
-// extractAttribute gets the name and value from a jsx_attribute node.
-func extractAttribute(node *ts.Node, source []byte) (string, string) {
-	var name, value string
+// extractAttribute gets the name, value, and value kind from a jsx_attribute node.
+func extractAttribute(node *ts.Node, source []byte) (string, string, string) {
+	var name, value, kind string
 
 	for i := uint(0); i < uint(node.ChildCount()); i++ {
 		child := node.Child(i)
@@ -246,25 +529,25 @@ func extractAttribute(node *ts.Node, source []byte) (string, string) {
 			name = child.Utf8Text(source)
 		case "string":
 			value = extractStringContent(child, source)
+			kind = ValueKindString
 		case "jsx_expression":
 			// Expression value like {() => {}} or {myVar}.
 			value = ""
+			kind = classifyExpression(child)
+		case "jsx_element", "jsx_self_closing_element", "jsx_fragment":
+			// Element value: icon=<Icon />
+			kind = ValueKindElement
 		}
 	}
 
 	// Boolean prop (no value): <Button asChild>
-	if name != "" && value == "" {
-		for i := uint(0); i < uint(node.ChildCount()); i++ {
-			child := node.Child(i)
-			if child.Kind() == "jsx_expression" || child.Kind() == "string" {
-				return name, value
-			}
-		}
+	if name != "" && kind == "" {
 		// Truly no value — boolean shorthand.
 		value = "true"
+		kind = ValueKindBoolean
 	}
 
-	return name, value
+	return name, value, kind
 }
 
 // hasJSXChildren checks if a jsx_element has any meaningful children (components or text).
@@ -288,6 +571,38 @@ func hasJSXChildren(node *ts.Node, source []byte) bool {
 	return false
 }
 
+// countJSXElements counts a jsx_element's direct element children.
+func countJSXElements(node *ts.Node) int {
+	count := 0
+	for i := uint(0); i < uint(node.ChildCount()); i++ {
+		if k := node.Child(i).Kind(); k == "jsx_element" || k == "jsx_self_closing_element" {
+			count++
+		}
+	}
+	return count
+}
+
+// hasJSXText checks if a jsx_element has direct text children: non-blank
+// text or a string literal expression such as {"Save"}.
+func hasJSXText(node *ts.Node, source []byte) bool {
+	for i := uint(0); i < uint(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch child.Kind() {
+		case "jsx_text":
+			if strings.TrimSpace(child.Utf8Text(source)) != "" {
+				return true
+			}
+		case "jsx_expression":
+			for j := uint(0); j < uint(child.ChildCount()); j++ {
+				if k := child.Child(j).Kind(); k == "string" || k == "template_string" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // isComponentName returns true if the tag name starts with an uppercase letter (React component convention).
 func isComponentName(name string) bool {
 	if name == "" {
@@ -296,6 +611,16 @@ func isComponentName(name string) bool {
 	return unicode.IsUpper(rune(name[0]))
 }
 
+// isIntrinsicName returns true for plain HTML element names such as "button".
+// Custom elements ("my-widget"), namespaced tags ("svg:rect"), and member
+// expressions are excluded.
+func isIntrinsicName(name string) bool {
+	if name == "" || !unicode.IsLower(rune(name[0])) {
+		return false
+	}
+	return !strings.ContainsAny(name, ".-:")
+}
+
 // currentParent returns the current parent component name from the stack, or "".
 func currentParent(stack []string) string {
 	if len(stack) == 0 {
@@ -303,3 +628,11 @@ func currentParent(stack []string) string {
 	}
 	return stack[len(stack)-1]
 }
+
+// ancestors returns a copy of the component stack, or nil if it is empty.
+func ancestors(stack []string) []string {
+	if len(stack) == 0 {
+		return nil
+	}
+	return append([]string(nil), stack...)
+}
@@ -82,6 +82,28 @@ func TestExtractJSX_HTMLElementsSkipped(t *testing.T) {
 	assert.Equal(t, "", ext.Usages[0].ParentComponent)
 }
 
+func TestExtractJSX_Intrinsics(t *testing.T) {
+	code := `
+<Card>
+  <button onClick={save}>
+    Save
+  </button>
+  <input />
+  <my-widget />
+  <svg:rect />
+</Card>
+`
+	ext := parseTSX(t, code)
+
+	require.Len(t, ext.Intrinsics, 2)
+	assert.Equal(t, "button", ext.Intrinsics[0].ComponentName)
+	assert.Equal(t, "Card", ext.Intrinsics[0].ParentComponent)
+	assert.Equal(t, 3, ext.Intrinsics[0].Line)
+	assert.Equal(t, 5, ext.Intrinsics[0].CloseLine)
+	assert.Equal(t, "input", ext.Intrinsics[1].ComponentName)
+	assert.Equal(t, 0, ext.Intrinsics[1].CloseLine)
+}
+
 func TestExtractJSX_ParentThroughHTML(t *testing.T) {
 	code := `
 <Dialog>
@@ -108,6 +130,56 @@ func TestExtractJSX_ExpressionProps(t *testing.T) {
 	assert.Equal(t, "", props["disabled"])
 }
 
+func TestExtractJSX_PropKinds(t *testing.T) {
+	code := `<Slider label="Volume" max={10} min={-1} disabled onChange={(v) => set(v)} style={{}} marks={[1, 2]} icon={<Icon />} value={value} format={fmt("x")} step={null} />`
+	ext := parseTSX(t, code)
+
+	require.Len(t, ext.Usages, 1)
+	assert.Equal(t, map[string]string{
+		"label":    ValueKindString,
+		"max":      ValueKindNumber,
+		"min":      ValueKindNumber,
+		"disabled": ValueKindBoolean,
+		"onChange": ValueKindFunction,
+		"style":    ValueKindObject,
+		"marks":    ValueKindArray,
+		"icon":     ValueKindElement,
+		"value":    ValueKindIdentifier,
+		"format":   ValueKindExpression,
+		"step":     ValueKindNull,
+	}, ext.Usages[0].PropKinds)
+}
+
+func TestExtractJSX_PropValues(t *testing.T) {
+	code := `
+const VARIANT = "outline" as const
+export const SIZE = isMobile ? "sm" : "lg";
+
+<Button
+  variant={VARIANT}
+  size={SIZE}
+  color={` + "`text-${tone || \"muted\"}`" + `}
+  tone={danger && "destructive"}
+  intent={level || "info"}
+  label={props.label}
+/>
+`
+	ext := parseTSX(t, code)
+
+	require.Len(t, ext.Usages, 1)
+	usage := ext.Usages[0]
+	assert.Equal(t, "outline", usage.Props["variant"])
+	assert.Equal(t, ValueKindString, usage.PropKinds["variant"])
+	assert.Equal(t, []string{"sm", "lg"}, usage.PropValues["size"])
+	assert.Equal(t, "", usage.Props["size"])
+	assert.Equal(t, []string{"text-muted"}, usage.PropValues["color"])
+	assert.Equal(t, ValueKindString, usage.PropKinds["color"])
+	assert.Equal(t, []string{"destructive"}, usage.PropValues["tone"])
+	assert.Equal(t, []string{"info"}, usage.PropValues["intent"])
+	assert.Equal(t, ValueKindExpression, usage.PropKinds["intent"], "partially resolved values keep their kind")
+	assert.NotContains(t, usage.PropValues, "label")
+}
+
 func TestExtractJSX_BooleanProp(t *testing.T) {
 	code := `<DialogTrigger asChild><Button>Open</Button></DialogTrigger>`
 	ext := parseTSX(t, code)
@@ -149,3 +221,56 @@ func TestExtractJSX_EmptyCode(t *testing.T) {
 
 	assert.Empty(t, ext.Usages)
 }
+
+func TestExtractJSX_AliasedAndNamespaceImports(t *testing.T) {
+	code := `
+import { Button as Btn, Badge } from "@/components/ui/button"
+import * as UI from "@/components/ui/dialog"
+import Card from "@/components/ui/card"
+
+export function Page() {
+  return (
+    <UI.Dialog>
+      <UI.DialogContent>
+        <Btn>Go</Btn>
+        <Card />
+      </UI.DialogContent>
+    </UI.Dialog>
+  )
+}
+`
+	ext := parseTSX(t, code)
+
+	require.Len(t, ext.Imports, 3)
+	assert.Equal(t, []string{"Button", "Badge"}, ext.Imports[0].Names)
+	assert.Equal(t, map[string]string{"Btn": "Button"}, ext.Imports[0].Aliases)
+	assert.Equal(t, "UI", ext.Imports[1].Namespace)
+
+	require.Len(t, ext.Usages, 4)
+	assert.Equal(t, "Dialog", ext.Usages[0].ComponentName)
+	assert.Equal(t, "UI.Dialog", ext.Usages[0].LocalName)
+	assert.Equal(t, "DialogContent", ext.Usages[1].ComponentName)
+	assert.Equal(t, "Dialog", ext.Usages[1].ParentComponent)
+	assert.Equal(t, "Button", ext.Usages[2].ComponentName)
+	assert.Equal(t, "Btn", ext.Usages[2].LocalName)
+	assert.Equal(t, "DialogContent", ext.Usages[2].ParentComponent)
+	assert.Equal(t, "Card", ext.Usages[3].ComponentName)
+	assert.Empty(t, ext.Usages[3].LocalName)
+}
+
+func TestResolveTag(t *testing.T) {
+	bindings := map[string]importBinding{
+		"Btn":    {source: "@/components/ui/button", exported: "Button"},
+		"UI":     {source: "@/components/ui", namespace: true},
+		"Card":   {source: "@/components/ui/card", exported: "default"},
+		"Select": {source: "@/components/ui/select", exported: "Select"},
+	}
+
+	assert.Equal(t, "Button", resolveTag("Btn", bindings))
+	assert.Equal(t, "DialogTitle", resolveTag("UI.DialogTitle", bindings))
+	assert.Equal(t, "UI", resolveTag("UI", bindings))
+	assert.Equal(t, "Card", resolveTag("Card", bindings))
+	assert.Equal(t, "Select.Item", resolveTag("Select.Item", bindings))
+	assert.Equal(t, "Other", resolveTag("Other", bindings))
+	assert.Equal(t, "", resolveTag("", bindings))
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/gnana997/uispec/pkg/markdown"
+	"github.com/gnana997/uispec/pkg/textdiff"
+)
+
+// documentKind reports whether a page is a Markdown or MDX document rather
+// than source code, going by the language hint or else the filename.
+func documentKind(language, filename string) (doc, mdx bool) {
+	ext := strings.ToLower(strings.TrimPrefix(language, "."))
+	if language == "" {
+		ext = strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	}
+	switch ext {
+	case "md", "markdown":
+		return true, false
+	case "mdx":
+		return true, true
+	}
+	return false, false
+}
+
+// validateDocument validates each code block of a Markdown or MDX document
+// as a page of its own. Violations and fixes are moved to the document's
+// positions, and fixes are applied to the whole document.
+func (v *Validator) validateDocument(doc string, mdx bool, opts ValidateOptions) *ValidationResult {
+	var violations []Violation
+	var fixes []AutoFix
+	for _, block := range markdown.Extract(doc, mdx) {
+		blockOpts := opts
+		blockOpts.Language = block.Language
+		blockOpts.FixFormat = FixFormatCode
+		if opts.Fix != nil {
+			blockOpts.Fix = func(viol Violation) bool {
+				return opts.Fix(docViolation(doc, block, viol))
+			}
+		}
+		r := v.ValidatePageWithOptions(block.Code, blockOpts)
+
+		base := len(violations)
+		for _, viol := range r.Violations {
+			violations = append(violations, docViolation(doc, block, viol))
+		}
+		for _, fix := range r.Fixes {
+			if !block.Contiguous(fix.StartByte, fix.EndByte) {
+				continue // e.g. an import added where MDX joins two sections
+			}
+			start := block.DocOffset(fix.StartByte)
+			s := span{start: start, end: start + fix.EndByte - fix.StartByte}
+			docFix := newFix(doc, s, fix.NewText, fix.Rule, fix.Reason, fix.Component)
+			docFix.Violation = base + fix.Violation
+			fixes = append(fixes, docFix)
+		}
+	}
+	sortViolations(violations, fixes)
+
+	result := &ValidationResult{
+		Valid:      len(filterBySeverity(violations, "error")) == 0,
+		Violations: violations,
+		Summary:    buildSummary(violations),
+	}
+	if len(fixes) > 0 {
+		sortFixes(fixes)
+		result.Fixes = fixes
+		fixedDoc := applyFixes(doc, fixes)
+		if opts.FixFormat == FixFormatDiff {
+			name := opts.Filename
+			if name == "" {
+				name = defaultDiffName
+			}
+			result.FixDiff = textdiff.Unified(name, name, doc, fixedDoc)
+		} else {
+			result.FixedCode = fixedDoc
+		}
+	}
+	return result
+}
+
+// analyzeDocument analyzes each code block of a Markdown or MDX document and
+// combines the summaries, with components at the document's lines.
+func (v *Validator) analyzeDocument(doc string, mdx bool) *PageAnalysis {
+	analysis := &PageAnalysis{
+		Components: []ComponentSummary{},
+		Imports:    []string{},
+		LineCount:  strings.Count(doc, "\n") + 1,
+	}
+	for _, block := range markdown.Extract(doc, mdx) {
+		a := v.AnalyzePageWithOptions(block.Code, AnalyzeOptions{Language: block.Language})
+		for _, comp := range a.Components {
+			comp.Line, _ = position(doc, block.DocOffset(offsetOf(block.Code, comp.Line, 1)))
+			analysis.Components = append(analysis.Components, comp)
+		}
+		for _, imp := range a.Imports {
+			if !containsName(analysis.Imports, imp) {
+				analysis.Imports = append(analysis.Imports, imp)
+			}
+		}
+	}
+	return analysis
+}
+
+// docViolation moves a violation in block to the document's positions.
+func docViolation(doc string, block markdown.Block, viol Violation) Violation {
+	viol.Line, viol.Column = position(doc, block.DocOffset(offsetOf(block.Code, viol.Line, viol.Column)))
+	if viol.EndLine > 0 {
+		viol.EndLine, _ = position(doc, block.DocOffset(offsetOf(block.Code, viol.EndLine, 1)))
+	}
+	return viol
+}
+
+// offsetOf returns the byte offset of a 1-based line and byte column,
+// clamped to the code. It is the inverse of position.
+func offsetOf(code string, line, column int) int {
+	offset := 0
+	for ; line > 1; line-- {
+		i := strings.IndexByte(code[offset:], '\n')
+		if i < 0 {
+			return len(code)
+		}
+		offset += i + 1
+	}
+	return min(offset+max(column-1, 0), len(code))
+}
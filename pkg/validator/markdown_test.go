@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentKind(t *testing.T) {
+	tests := []struct {
+		language, filename string
+		doc, mdx           bool
+	}{
+		{"", "docs/button.md", true, false},
+		{"", "docs/Button.MDX", true, true},
+		{"markdown", "", true, false},
+		{".mdx", "page.tsx", true, true},
+		{"tsx", "docs/button.md", false, false},
+		{"", "src/page.tsx", false, false},
+	}
+	for _, tt := range tests {
+		doc, mdx := documentKind(tt.language, tt.filename)
+		assert.Equal(t, tt.doc, doc, "%s %s", tt.language, tt.filename)
+		assert.Equal(t, tt.mdx, mdx, "%s %s", tt.language, tt.filename)
+	}
+	assert.True(t, ValidLanguage("mdx"))
+}
+
+func TestOffsetOf(t *testing.T) {
+	code := "ab\ncde\n"
+	for offset := 0; offset <= len(code); offset++ {
+		line, col := position(code, offset)
+		assert.Equal(t, offset, offsetOf(code, line, col))
+	}
+	assert.Equal(t, len(code), offsetOf(code, 9, 1))
+}
+
+func TestValidatePage_Markdown(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	doc := "# Buttons\n" +
+		"\n" +
+		"```tsx\n" +
+		"import { Button } from \"@/components/ui/button\"\n" +
+		"\n" +
+		"export const Danger = () => <Button variant=\"destuctive\">Delete</Button>\n" +
+		"```\n" +
+		"\n" +
+		"```tsx uispec-ignore\n" +
+		"<Button variant=\"fancy\">Don't</Button>\n" +
+		"```\n"
+
+	result := v.ValidatePageWithOptions(doc, ValidateOptions{Filename: "docs/buttons.md", AutoFix: true})
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "invalid-prop-value", result.Violations[0].Rule)
+	assert.Equal(t, 6, result.Violations[0].Line, "mapped to the document's line")
+
+	require.Len(t, result.Fixes, 1)
+	assert.Equal(t, 6, result.Fixes[0].Line)
+	assert.Contains(t, result.FixedCode, `<Button variant="destructive">Delete</Button>`)
+	assert.Contains(t, result.FixedCode, `<Button variant="fancy">Don't</Button>`, "ignored blocks are left alone")
+}
+
+func TestValidatePage_MarkdownFixFilter(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	doc := "# Buttons\n" +
+		"\n" +
+		"```tsx\n" +
+		"<Button\n" +
+		"  variant=\"destuctive\"\n" +
+		">Delete</Button>\n" +
+		"```\n"
+
+	seen := make(map[string]Violation)
+	result := v.ValidatePageWithOptions(doc, ValidateOptions{
+		Filename: "docs/buttons.md",
+		AutoFix:  true,
+		Fix: func(viol Violation) bool {
+			seen[viol.Rule] = viol
+			return false
+		},
+	})
+
+	require.Contains(t, seen, "invalid-prop-value")
+	assert.Equal(t, 4, seen["invalid-prop-value"].Line, "the filter sees the document's lines")
+	assert.Equal(t, 6, seen["invalid-prop-value"].EndLine)
+	assert.Len(t, result.Violations, len(seen))
+	assert.Empty(t, result.Fixes)
+}
+
+func TestValidatePage_MDX(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	doc := "import { Button } from \"@/components/ui/button\"\n" +
+		"\n" +
+		"# Buttons\n" +
+		"\n" +
+		"<Button variant=\"fancy\">Go</Button>\n" +
+		"\n" +
+		"<Dialog>Not imported</Dialog>\n"
+
+	result := v.ValidatePageWithOptions(doc, ValidateOptions{Filename: "docs/buttons.mdx"})
+
+	lines := make(map[string]int)
+	for _, viol := range result.Violations {
+		lines[viol.Rule] = viol.Line
+	}
+	assert.Equal(t, 5, lines["invalid-prop-value"])
+	assert.Equal(t, 7, lines["missing-import"])
+}
@@ -0,0 +1,200 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+
+	"github.com/gnana997/uispec/pkg/catalog"
+)
+
+// Value kinds recorded in JSXUsage.PropKinds.
+const (
+	ValueKindString     = "string"
+	ValueKindNumber     = "number"
+	ValueKindBoolean    = "boolean"
+	ValueKindFunction   = "function"
+	ValueKindObject     = "object"
+	ValueKindArray      = "array"
+	ValueKindElement    = "element"
+	ValueKindNull       = "null"       // null or undefined
+	ValueKindIdentifier = "identifier" // a variable whose type is unknown
+	ValueKindExpression = "expression" // any other expression (call, member access, ternary, ...)
+)
+
+// classifyExpression returns the value kind of a jsx_expression attribute value.
+func classifyExpression(node *ts.Node) string {
+	for i := uint(0); i < uint(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch child.Kind() {
+		case "{", "}", "comment":
+			continue
+		case "string", "template_string":
+			return ValueKindString
+		case "number":
+			return ValueKindNumber
+		case "unary_expression":
+			// -1, +2
+			if arg := child.ChildByFieldName("argument"); arg != nil && arg.Kind() == "number" {
+				return ValueKindNumber
+			}
+			return ValueKindExpression
+		case "true", "false":
+			return ValueKindBoolean
+		case "arrow_function", "function_expression", "function":
+			return ValueKindFunction
+		case "object":
+			return ValueKindObject
+		case "array":
+			return ValueKindArray
+		case "jsx_element", "jsx_self_closing_element", "jsx_fragment":
+			return ValueKindElement
+		case "null", "undefined":
+			return ValueKindNull
+		case "identifier":
+			return ValueKindIdentifier
+		default:
+			return ValueKindExpression
+		}
+	}
+	return ValueKindExpression
+}
+
+// literalKinds are the value kinds whose type is known from the syntax alone;
+// only these are checked against the catalog prop type.
+var literalKinds = map[string]bool{
+	ValueKindString:   true,
+	ValueKindNumber:   true,
+	ValueKindBoolean:  true,
+	ValueKindFunction: true,
+	ValueKindObject:   true,
+	ValueKindArray:    true,
+	ValueKindElement:  true,
+}
+
+// acceptedKinds returns the value kinds a catalog prop type accepts. ok is
+// false when the type is not understood (e.g. "Date" or a custom interface),
+// in which case no type check is made.
+func acceptedKinds(propType string) (kinds map[string]bool, ok bool) {
+	members := splitUnion(propType)
+	if len(members) == 0 {
+		return nil, false
+	}
+
+	kinds = make(map[string]bool)
+	for _, member := range members {
+		member = strings.TrimPrefix(member, "React.")
+		switch {
+		case member == "undefined" || member == "null":
+			// Optional marker; null/undefined values are never flagged.
+		case member == "string" || isQuoted(member) || strings.HasPrefix(member, "`"):
+			kinds[ValueKindString] = true
+		case member == "number" || isNumberLiteral(member):
+			kinds[ValueKindNumber] = true
+		case member == "boolean" || member == "true" || member == "false":
+			kinds[ValueKindBoolean] = true
+		case member == "function" || member == "Function" || strings.Contains(member, "=>") ||
+			strings.HasSuffix(member, "Handler") || strings.Contains(member, "Handler<"):
+			kinds[ValueKindFunction] = true
+		case strings.HasSuffix(member, "[]") || strings.HasPrefix(member, "Array<") || strings.HasPrefix(member, "readonly "):
+			kinds[ValueKindArray] = true
+		case member == "object" || strings.HasPrefix(member, "{") || strings.HasPrefix(member, "Record<") ||
+			member == "CSSProperties":
+			kinds[ValueKindObject] = true
+		case member == "ReactNode":
+			for _, k := range []string{ValueKindString, ValueKindNumber, ValueKindBoolean, ValueKindElement, ValueKindArray} {
+				kinds[k] = true
+			}
+		case member == "ReactElement" || strings.HasPrefix(member, "ReactElement<") || member == "JSX.Element":
+			kinds[ValueKindElement] = true
+		default:
+			return nil, false
+		}
+	}
+	return kinds, true
+}
+
+// splitUnion splits a TypeScript type on top-level "|" separators, ignoring
+// those nested in parentheses, brackets, braces, or generics.
+func splitUnion(t string) []string {
+	var members []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(t); i++ {
+		c := t[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '(' || c == '[' || c == '{' || c == '<':
+			depth++
+		case c == ')' || c == ']' || c == '}' || (c == '>' && (i == 0 || t[i-1] != '=')):
+			depth--
+		case c == '|' && depth == 0:
+			members = append(members, strings.TrimSpace(t[start:i]))
+			start = i + 1
+		}
+	}
+	members = append(members, strings.TrimSpace(t[start:]))
+
+	result := members[:0]
+	for _, m := range members {
+		if m != "" {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// isQuoted reports whether s is a quoted string literal type.
+func isQuoted(s string) bool {
+	return len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0]
+}
+
+// isNumberLiteral reports whether s is a numeric literal type.
+func isNumberLiteral(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// checkPropType flags a prop whose literal value kind is not accepted by its
+// catalog type, e.g. max="10" for a number prop.
+func checkPropType(usage JSXUsage, propName string, def *catalog.Prop) *Violation {
+	kind := usage.PropKinds[propName]
+	if !literalKinds[kind] {
+		return nil
+	}
+	accepted, ok := acceptedKinds(def.Type)
+	if !ok || accepted[kind] {
+		return nil
+	}
+
+	got := kind
+	if kind == ValueKindString {
+		got = fmt.Sprintf("string %q", usage.Props[propName])
+	}
+
+	var suggestion string
+	value := usage.Props[propName]
+	switch {
+	case kind == ValueKindString && accepted[ValueKindNumber] && isNumberLiteral(value):
+		suggestion = fmt.Sprintf("Use %s={%s}", propName, value)
+	case kind == ValueKindString && accepted[ValueKindBoolean] && (value == "true" || value == "false"):
+		suggestion = fmt.Sprintf("Use %s={%s}", propName, value)
+	}
+
+	return &Violation{
+		Rule:       "prop-type-mismatch",
+		Message:    fmt.Sprintf("Prop %q on %q expects %s but got %s", propName, usage.ComponentName, def.Type, got),
+		Severity:   "error",
+		Line:       usage.Line,
+		Column:     usage.Column,
+		Component:  usage.ComponentName,
+		Suggestion: suggestion,
+	}
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnana997/uispec/pkg/catalog"
+)
+
+func TestAcceptedKinds(t *testing.T) {
+	tests := []struct {
+		propType string
+		want     []string
+		ok       bool
+	}{
+		{"boolean", []string{ValueKindBoolean}, true},
+		{"number | undefined", []string{ValueKindNumber}, true},
+		{`"default" | "sm" | "lg"`, []string{ValueKindString}, true},
+		{"(value: string | number) => void", []string{ValueKindFunction}, true},
+		{"MouseEventHandler<HTMLButtonElement>", []string{ValueKindFunction}, true},
+		{"number[]", []string{ValueKindArray}, true},
+		{"React.CSSProperties", []string{ValueKindObject}, true},
+		{"React.ReactNode", []string{ValueKindString, ValueKindNumber, ValueKindBoolean, ValueKindElement, ValueKindArray}, true},
+		{"string | Date", nil, false},
+		{"", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.propType, func(t *testing.T) {
+			kinds, ok := acceptedKinds(tt.propType)
+			require.Equal(t, tt.ok, ok)
+			got := make([]string, 0, len(kinds))
+			for k := range kinds {
+				got = append(got, k)
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplitUnion(t *testing.T) {
+	assert.Equal(t, []string{"(a: A | B) => void", "undefined"}, splitUnion("(a: A | B) => void | undefined"))
+	assert.Equal(t, []string{`"a|b"`, "string"}, splitUnion(`"a|b" | string`))
+	assert.Equal(t, []string{"Array<string | number>"}, splitUnion("Array<string | number>"))
+}
+
+func TestCheckPropType(t *testing.T) {
+	usage := JSXUsage{
+		ComponentName: "Slider",
+		Props:         map[string]string{"max": "10", "onValueChange": "go", "disabled": "true", "step": ""},
+		PropKinds: map[string]string{
+			"max":           ValueKindString,
+			"onValueChange": ValueKindString,
+			"disabled":      ValueKindBoolean,
+			"step":          ValueKindIdentifier,
+		},
+	}
+
+	v := checkPropType(usage, "max", &catalog.Prop{Name: "max", Type: "number"})
+	require.NotNil(t, v)
+	assert.Equal(t, "prop-type-mismatch", v.Rule)
+	assert.Equal(t, `Prop "max" on "Slider" expects number but got string "10"`, v.Message)
+	assert.Equal(t, "Use max={10}", v.Suggestion)
+
+	v = checkPropType(usage, "onValueChange", &catalog.Prop{Name: "onValueChange", Type: "function"})
+	require.NotNil(t, v)
+	assert.Empty(t, v.Suggestion)
+
+	assert.Nil(t, checkPropType(usage, "disabled", &catalog.Prop{Name: "disabled", Type: "boolean"}))
+	assert.Nil(t, checkPropType(usage, "step", &catalog.Prop{Name: "step", Type: "number"}), "identifiers are not checked")
+	assert.Nil(t, checkPropType(usage, "max", &catalog.Prop{Name: "max", Type: "Date"}), "unknown types are not checked")
+}
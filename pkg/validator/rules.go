@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"fmt"
+	"sync"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+
+	"github.com/gnana997/uispec/pkg/catalog"
+)
+
+// RuleInfo describes a validation rule reported by the validator.
+type RuleInfo struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"` // default severity: "error", "warning", "info"
+}
+
+// Rule is a check run against every validated page. The built-in checks
+// implement it, and Register adds organisation-specific ones.
+type Rule interface {
+	// Info describes the rule ids the check reports.
+	Info() []RuleInfo
+	// Check returns the page's violations. A violation with no Severity
+	// gets its rule's default, and one with Edits can be auto-fixed.
+	Check(page *Page) []Violation
+}
+
+// Page is a parsed page as seen by a Rule. Sub-component aliases such as
+// <Dialog.Trigger> and transparent wrappers are already resolved in
+// Extraction. Rules must not modify the page.
+type Page struct {
+	// Filename is the page's path relative to the project root; may be empty.
+	Filename   string
+	Source     []byte
+	Tree       *ts.Tree
+	Extraction *JSXExtraction
+	Catalog    *catalog.Catalog
+	Index      *catalog.CatalogIndex
+
+	bindings map[string]importBinding // local identifier → import
+	tokens   *tokenMatcher            // nil when the catalog has no checkable tokens
+}
+
+// Edit replaces the source bytes [StartByte, EndByte) with NewText; an empty
+// range is an insertion.
+type Edit struct {
+	StartByte int
+	EndByte   int
+	NewText   string
+}
+
+// parseErrorRule and unusedSuppressionRule are reported by the validator
+// itself rather than by a Rule.
+var (
+	parseErrorRule        = RuleInfo{ID: "parse-error", Description: "The source code could not be parsed", Severity: "error"}
+	unusedSuppressionRule = RuleInfo{ID: "unused-suppression", Description: "A uispec-disable comment does not suppress any violation", Severity: "warning"}
+)
+
+// builtinChecks run on every page, in this order.
+var builtinChecks = []Rule{
+	componentRule{},
+	importRule{},
+	propsRule{},
+	constraintRule{},
+	compositionRule{},
+	mustContainRule{},
+	childrenRule{},
+	intrinsicsRule{},
+	tokensRule{},
+}
+
+// customChecks holds the rules added with Register.
+var customChecks struct {
+	sync.RWMutex
+	rules []Rule
+}
+
+// Register adds a custom rule to every validator, run after the built-in
+// checks. It panics if the rule reports no ids or an id that is already
+// registered, so it is best called from an init function.
+func Register(rule Rule) {
+	customChecks.Lock()
+	defer customChecks.Unlock()
+
+	infos := rule.Info()
+	if len(infos) == 0 {
+		panic("validator: Register called with a rule that reports no ids")
+	}
+	for _, info := range infos {
+		if _, ok := lookupRule(info.ID); ok {
+			panic(fmt.Sprintf("validator: rule %q is already registered", info.ID))
+		}
+	}
+	customChecks.rules = append(customChecks.rules, rule)
+}
+
+// checks returns the built-in checks followed by the registered ones.
+func checks() []Rule {
+	customChecks.RLock()
+	defer customChecks.RUnlock()
+	out := make([]Rule, 0, len(builtinChecks)+len(customChecks.rules))
+	out = append(out, builtinChecks...)
+	return append(out, customChecks.rules...)
+}
+
+// Rules returns metadata for all validation rules, built-in rules first in
+// display order, then registered ones.
+func Rules() []RuleInfo {
+	customChecks.RLock()
+	defer customChecks.RUnlock()
+	return allRules()
+}
+
+// LookupRule returns metadata for the rule with the given ID.
+func LookupRule(id string) (RuleInfo, bool) {
+	customChecks.RLock()
+	defer customChecks.RUnlock()
+	return lookupRule(id)
+}
+
+// allRules lists every rule. The caller must hold customChecks.
+func allRules() []RuleInfo {
+	out := []RuleInfo{parseErrorRule}
+	for _, check := range builtinChecks {
+		out = append(out, check.Info()...)
+	}
+	out = append(out, unusedSuppressionRule)
+	for _, check := range customChecks.rules {
+		out = append(out, check.Info()...)
+	}
+	return out
+}
+
+// lookupRule is LookupRule for a caller that holds customChecks.
+func lookupRule(id string) (RuleInfo, bool) {
+	for _, r := range allRules() {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return RuleInfo{}, false
+}
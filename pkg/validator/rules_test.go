@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// todoRule is a custom rule that flags TODO comments and fixes them by
+// deleting the marker.
+type todoRule struct{}
+
+func (todoRule) Info() []RuleInfo {
+	return []RuleInfo{{ID: "test-no-todo", Description: "TODO left in the page", Severity: "info"}}
+}
+
+func (todoRule) Check(page *Page) []Violation {
+	var violations []Violation
+	source := string(page.Source)
+	if i := strings.Index(source, "TODO "); i >= 0 {
+		violations = append(violations, Violation{
+			Rule:    "test-no-todo",
+			Message: "Resolve the TODO",
+			Line:    1,
+			Column:  i + 1,
+			Edits:   []Edit{{StartByte: i, EndByte: i + len("TODO ")}},
+		})
+	}
+	return violations
+}
+
+func TestRules_Builtin(t *testing.T) {
+	rules := Rules()
+	require.NotEmpty(t, rules)
+	assert.Equal(t, "parse-error", rules[0].ID)
+
+	seen := make(map[string]bool)
+	for _, r := range rules {
+		assert.False(t, seen[r.ID], "duplicate rule %q", r.ID)
+		seen[r.ID] = true
+		assert.NotEmpty(t, r.Description, r.ID)
+		assert.Contains(t, []string{"error", "warning", "info"}, r.Severity, r.ID)
+	}
+	for _, check := range builtinChecks {
+		for _, info := range check.Info() {
+			assert.True(t, seen[info.ID], info.ID)
+		}
+	}
+
+	info, ok := LookupRule("unused-suppression")
+	require.True(t, ok)
+	assert.Equal(t, "warning", info.Severity)
+	_, ok = LookupRule("no-such-rule")
+	assert.False(t, ok)
+}
+
+func TestRegister(t *testing.T) {
+	customChecks.RLock()
+	saved := customChecks.rules
+	customChecks.RUnlock()
+	t.Cleanup(func() {
+		customChecks.Lock()
+		customChecks.rules = saved
+		customChecks.Unlock()
+	})
+
+	Register(todoRule{})
+
+	info, ok := LookupRule("test-no-todo")
+	require.True(t, ok)
+	assert.Equal(t, "info", info.Severity)
+	assert.Equal(t, "test-no-todo", Rules()[len(Rules())-1].ID)
+	assert.IsType(t, todoRule{}, checks()[len(checks())-1])
+
+	assert.Panics(t, func() { Register(todoRule{}) }, "duplicate id")
+	assert.Panics(t, func() { Register(compositionRule{}) }, "built-in id")
+
+	// The rule's default severity fills in an empty one.
+	page := &Page{Source: []byte("// TODO fix\n<Button />"), Extraction: &JSXExtraction{}}
+	violations := runCheck(todoRule{}, page)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "info", violations[0].Severity)
+}
+
+func TestGenerateFixes_RuleEdits(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := "// TODO fix\n<Button />"
+	violations := (todoRule{}).Check(&Page{Source: []byte(code)})
+
+	fixes, fixed := GenerateFixes(code, violations, v.index)
+
+	require.Len(t, fixes, 1)
+	assert.Equal(t, "test-no-todo", fixes[0].Rule)
+	assert.Equal(t, "Resolve the TODO", fixes[0].Reason)
+	assert.Equal(t, "// fix\n<Button />", fixed)
+
+	violations[0].Edits[0].EndByte = len(code) + 1
+	fixes, _ = GenerateFixes(code, violations, v.index)
+	assert.Empty(t, fixes, "out-of-range edits are ignored")
+}
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// suggestMatches returns the candidates name is most likely a misspelling
+// of: those equal to it ignoring case if there are any, otherwise those at
+// the smallest edit distance within maxSuggestDistance. The result is sorted;
+// a single entry is an unambiguous match.
+func suggestMatches(name string, candidates []string) []string {
+	if name == "" {
+		return nil
+	}
+	lower := strings.ToLower(name)
+
+	var matches []string
+	limit := maxSuggestDistance(name)
+	best := limit
+	for _, candidate := range candidates {
+		if candidate == name || candidate == "" {
+			continue
+		}
+		d := editDistance(lower, strings.ToLower(candidate))
+		switch {
+		case d > limit:
+			continue
+		case d < best:
+			best = d
+			matches = []string{candidate}
+		case d == best:
+			matches = appendUnique(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// maxSuggestDistance returns the largest edit distance at which a candidate
+// is still treated as a misspelling of name. Very short names only match
+// when they differ in case.
+func maxSuggestDistance(name string) int {
+	switch n := len([]rune(name)); {
+	case n <= 2:
+		return 0
+	case n < 6:
+		return 1
+	case n < 12:
+		return 2
+	}
+	return 3
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: insertions, deletions, substitutions, and swaps of adjacent characters
+// each cost 1.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// Three rolling rows: two back (for transpositions), previous, current.
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+// didYouMean formats matches as a suggestion, each rendered with format,
+// e.g. `Did you mean "variant"?` or `Did you mean <Badge> or <Button>?`.
+func didYouMean(format string, matches []string) string {
+	quoted := make([]string, len(matches))
+	for i, m := range matches {
+		quoted[i] = fmt.Sprintf(format, m)
+	}
+	return "Did you mean " + strings.Join(quoted, " or ") + "?"
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"button", "button", 0},
+		{"buton", "button", 1},
+		{"varient", "variant", 1},
+		{"destuctive", "destructive", 1},
+		{"buttno", "button", 1}, // adjacent swap
+		{"chart", "card", 2},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, editDistance(tt.a, tt.b), "%s → %s", tt.a, tt.b)
+	}
+}
+
+func TestSuggestMatches(t *testing.T) {
+	components := []string{"Badge", "Button", "Card", "Dialog", "DialogContent", "Dialog.Content"}
+
+	assert.Equal(t, []string{"Button"}, suggestMatches("Buton", components))
+	assert.Equal(t, []string{"Button"}, suggestMatches("button", components))
+	assert.Equal(t, []string{"DialogContent"}, suggestMatches("DialogContnet", components))
+	assert.Equal(t, []string{"Dialog.Content"}, suggestMatches("Dialog.Contnt", components))
+	assert.Empty(t, suggestMatches("Chart", components))
+	assert.Empty(t, suggestMatches("Button", components))
+
+	// Ties are all returned, sorted.
+	assert.Equal(t, []string{"side", "size"}, suggestMatches("sise", []string{"size", "side", "variant"}))
+
+	// Short values only match by case.
+	assert.Equal(t, []string{"lg"}, suggestMatches("LG", []string{"sm", "lg"}))
+	assert.Empty(t, suggestMatches("xs", []string{"sm", "lg"}))
+}
+
+func TestDidYouMean(t *testing.T) {
+	assert.Equal(t, `Did you mean "variant"?`, didYouMean("%q", []string{"variant"}))
+	assert.Equal(t, "Did you mean <Badge> or <Button>?", didYouMean("<%s>", []string{"Badge", "Button"}))
+}
@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Suppression directive kinds, written in comments as:
+//
+//	// uispec-disable-next-line unknown-prop
+//	{/* uispec-disable-line */}
+//	/* uispec-disable unknown-prop, composition-violation */
+const (
+	directiveDisableNextLine = "uispec-disable-next-line"
+	directiveDisableLine     = "uispec-disable-line"
+	directiveDisable         = "uispec-disable"
+)
+
+// suppression is a parsed uispec-disable directive found in a comment.
+type suppression struct {
+	directive string
+	rules     []string // empty means all rules
+	fileWide  bool
+	line      int // 1-based target line (unused when fileWide)
+
+	commentLine   int
+	commentColumn int
+
+	used map[string]bool // rule → suppressed at least one violation
+}
+
+// parseDirective extracts a suppression directive from raw comment text.
+// Rules may be separated by commas and/or whitespace; anything after "--" is
+// treated as a free-form reason and ignored.
+func parseDirective(comment string) (directive string, rules []string, ok bool) {
+	text := strings.TrimSpace(comment)
+	switch {
+	case strings.HasPrefix(text, "//"):
+		text = text[2:]
+	case strings.HasPrefix(text, "/*"):
+		text = strings.TrimSuffix(text[2:], "*/")
+	}
+	text = strings.TrimSpace(text)
+
+	for _, d := range []string{directiveDisableNextLine, directiveDisableLine, directiveDisable} {
+		rest, found := strings.CutPrefix(text, d)
+		if !found {
+			continue
+		}
+		if rest != "" && rest[0] != ' ' && rest[0] != '\t' && rest[0] != '\n' {
+			// e.g. "uispec-disable-foo" is not a directive.
+			return "", nil, false
+		}
+		if idx := strings.Index(rest, "--"); idx >= 0 {
+			rest = rest[:idx]
+		}
+		for _, field := range strings.FieldsFunc(rest, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '*'
+		}) {
+			rules = append(rules, field)
+		}
+		return d, rules, true
+	}
+	return "", nil, false
+}
+
+// collectSuppressions walks the AST and returns every suppression directive
+// found in comment nodes, in source order.
+func collectSuppressions(root *ts.Node, source []byte) []*suppression {
+	var result []*suppression
+	var walk func(node *ts.Node)
+	walk = func(node *ts.Node) {
+		if node.Kind() == "comment" {
+			directive, rules, ok := parseDirective(node.Utf8Text(source))
+			if ok {
+				s := &suppression{
+					directive:     directive,
+					rules:         rules,
+					commentLine:   int(node.StartPosition().Row) + 1,
+					commentColumn: int(node.StartPosition().Column) + 1,
+					used:          make(map[string]bool),
+				}
+				switch directive {
+				case directiveDisable:
+					s.fileWide = true
+				case directiveDisableLine:
+					s.line = s.commentLine
+				case directiveDisableNextLine:
+					s.line = int(node.EndPosition().Row) + 2
+				}
+				result = append(result, s)
+			}
+			return
+		}
+		for i := uint(0); i < uint(node.ChildCount()); i++ {
+			walk(node.Child(i))
+		}
+	}
+	walk(root)
+	return result
+}
+
+// matches reports whether the suppression covers the violation, recording use.
+func (s *suppression) matches(v Violation) bool {
+	if !s.fileWide && s.line != v.Line {
+		return false
+	}
+	if len(s.rules) == 0 {
+		s.used[""] = true
+		return true
+	}
+	for _, rule := range s.rules {
+		if rule == v.Rule {
+			s.used[rule] = true
+			return true
+		}
+	}
+	return false
+}
+
+// applySuppressions removes suppressed violations and appends an
+// unused-suppression violation for every directive (or listed rule) that
+// did not suppress anything.
+func applySuppressions(violations []Violation, suppressions []*suppression) []Violation {
+	if len(suppressions) == 0 {
+		return violations
+	}
+
+	kept := make([]Violation, 0, len(violations))
+	for _, v := range violations {
+		suppressed := false
+		for _, s := range suppressions {
+			// Evaluate every directive so that each one records its use.
+			if s.matches(v) {
+				suppressed = true
+			}
+		}
+		if !suppressed {
+			kept = append(kept, v)
+		}
+	}
+
+	for _, s := range suppressions {
+		if len(s.rules) == 0 {
+			if !s.used[""] {
+				kept = append(kept, s.unused(fmt.Sprintf("%s directive does not suppress any violation", s.directive), ""))
+			}
+			continue
+		}
+		for _, rule := range s.rules {
+			if !s.used[rule] {
+				kept = append(kept, s.unused(fmt.Sprintf("%s for rule %q does not suppress any violation", s.directive, rule), rule))
+			}
+		}
+	}
+
+	return kept
+}
+
+// unused builds an unused-suppression violation located at the comment.
+func (s *suppression) unused(message, rule string) Violation {
+	suggestion := "Remove the unused directive"
+	if rule != "" && len(s.rules) > 1 {
+		suggestion = fmt.Sprintf("Remove %q from the directive", rule)
+	}
+	return Violation{
+		Rule:       "unused-suppression",
+		Message:    message,
+		Severity:   "warning",
+		Line:       s.commentLine,
+		Column:     s.commentColumn,
+		Suggestion: suggestion,
+	}
+}
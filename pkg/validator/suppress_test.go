@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDirective(t *testing.T) {
+	tests := []struct {
+		comment   string
+		directive string
+		rules     []string
+		ok        bool
+	}{
+		{"// uispec-disable-next-line unknown-prop", directiveDisableNextLine, []string{"unknown-prop"}, true},
+		{"/* uispec-disable-line */", directiveDisableLine, nil, true},
+		{"/* uispec-disable rule-a, rule-b */", directiveDisable, []string{"rule-a", "rule-b"}, true},
+		{"// uispec-disable-next-line unknown-prop -- legacy markup", directiveDisableNextLine, []string{"unknown-prop"}, true},
+		{"// uispec-disable-foo", "", nil, false},
+		{"// regular comment", "", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.comment, func(t *testing.T) {
+			directive, rules, ok := parseDirective(tt.comment)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.directive, directive)
+			assert.Equal(t, tt.rules, rules)
+		})
+	}
+}
+
+func TestApplySuppressions(t *testing.T) {
+	violations := []Violation{
+		{Rule: "unknown-prop", Line: 3},
+		{Rule: "composition-violation", Line: 3},
+		{Rule: "unknown-component", Line: 7},
+	}
+	suppressions := []*suppression{
+		{directive: directiveDisableNextLine, rules: []string{"unknown-prop"}, line: 3, commentLine: 2, used: map[string]bool{}},
+		{directive: directiveDisable, rules: []string{"unknown-component", "missing-child"}, fileWide: true, commentLine: 1, used: map[string]bool{}},
+	}
+
+	kept := applySuppressions(violations, suppressions)
+
+	require.Len(t, kept, 2)
+	assert.Equal(t, "composition-violation", kept[0].Rule)
+	assert.Equal(t, "unused-suppression", kept[1].Rule)
+	assert.Equal(t, 1, kept[1].Line)
+	assert.Contains(t, kept[1].Message, "missing-child")
+}
+
+func TestApplySuppressions_UnusedLineDirective(t *testing.T) {
+	suppressions := []*suppression{
+		{directive: directiveDisableLine, line: 5, commentLine: 5, commentColumn: 9, used: map[string]bool{}},
+	}
+
+	kept := applySuppressions([]Violation{{Rule: "unknown-prop", Line: 6}}, suppressions)
+
+	require.Len(t, kept, 2)
+	assert.Equal(t, "unknown-prop", kept[0].Rule)
+	assert.Equal(t, "unused-suppression", kept[1].Rule)
+	assert.Equal(t, 9, kept[1].Column)
+}
+
+func TestValidatePage_SuppressNextLine(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  // uispec-disable-next-line invalid-prop-value
+  return <Button variant="fancy">Click</Button>
+}
+`
+	result := v.ValidatePage(code, false)
+	assert.Empty(t, result.Violations)
+}
+
+func TestValidatePage_SuppressInJSX(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  return (
+    <div>
+      <Button variant="fancy">Click</Button> {/* uispec-disable-line */}
+    </div>
+  )
+}
+`
+	result := v.ValidatePage(code, false)
+	assert.Empty(t, result.Violations)
+}
+
+func TestValidatePage_UnusedSuppression(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `/* uispec-disable unknown-component */
+import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  return <Button>Click</Button>
+}
+`
+	result := v.ValidatePage(code, false)
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "unused-suppression", result.Violations[0].Rule)
+	assert.Equal(t, 1, result.Violations[0].Line)
+}
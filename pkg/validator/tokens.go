@@ -0,0 +1,481 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	ts "github.com/tree-sitter/go-tree-sitter"
+
+	"github.com/gnana997/uispec/pkg/catalog"
+)
+
+// Matching thresholds for suggesting a design token.
+const (
+	// colorMatchThreshold is the largest CIEDE2000 difference for which the
+	// nearest color token is suggested.
+	colorMatchThreshold = 10.0
+	// lengthMatchTolerance is the largest difference, in pixels, for which the
+	// nearest spacing or radius token is suggested.
+	lengthMatchTolerance = 2.0
+	// remPixels converts rem lengths to pixels.
+	remPixels = 16.0
+)
+
+// Kinds of hardcoded values the token rule looks for.
+const (
+	literalColor   = "color"
+	literalSpacing = "spacing"
+	literalRadius  = "radius"
+)
+
+var (
+	colorLiteralRE  = regexp.MustCompile(`#[0-9a-fA-F]{3,8}\b|\b(?:rgba?|hsla?|oklch)\([^()]*\)`)
+	lengthLiteralRE = regexp.MustCompile(`-?(?:\d+\.?\d*|\.\d+)(?:px|rem)\b`)
+)
+
+// spacingStyleProps and radiusStyleProps are the inline style properties whose
+// lengths are checked against spacing and radius tokens.
+var (
+	spacingStyleProps = map[string]bool{
+		"padding": true, "paddingTop": true, "paddingRight": true, "paddingBottom": true, "paddingLeft": true,
+		"paddingInline": true, "paddingBlock": true, "paddingInlineStart": true, "paddingInlineEnd": true,
+		"margin": true, "marginTop": true, "marginRight": true, "marginBottom": true, "marginLeft": true,
+		"marginInline": true, "marginBlock": true, "marginInlineStart": true, "marginInlineEnd": true,
+		"gap": true, "rowGap": true, "columnGap": true,
+		"inset": true, "top": true, "right": true, "bottom": true, "left": true,
+	}
+	radiusStyleProps = map[string]bool{
+		"borderRadius": true, "borderTopLeftRadius": true, "borderTopRightRadius": true,
+		"borderBottomLeftRadius": true, "borderBottomRightRadius": true,
+	}
+)
+
+// spacingUtilities and radiusUtilities are the Tailwind utilities whose
+// arbitrary values (e.g. "p-[13px]") are checked against spacing and radius tokens.
+var (
+	spacingUtilities = map[string]bool{
+		"p": true, "px": true, "py": true, "pt": true, "pr": true, "pb": true, "pl": true, "ps": true, "pe": true,
+		"m": true, "mx": true, "my": true, "mt": true, "mr": true, "mb": true, "ml": true, "ms": true, "me": true,
+		"gap": true, "gap-x": true, "gap-y": true, "space-x": true, "space-y": true,
+		"inset": true, "inset-x": true, "inset-y": true, "top": true, "right": true, "bottom": true, "left": true,
+	}
+	radiusUtilities = map[string]bool{
+		"rounded": true, "rounded-t": true, "rounded-r": true, "rounded-b": true, "rounded-l": true,
+		"rounded-tl": true, "rounded-tr": true, "rounded-br": true, "rounded-bl": true,
+		"rounded-s": true, "rounded-e": true, "rounded-ss": true, "rounded-se": true, "rounded-es": true, "rounded-ee": true,
+	}
+)
+
+// colorToken is a catalog token with a resolvable color value.
+type colorToken struct {
+	token   catalog.Token
+	lab     labColor
+	triplet bool // value is a bare HSL triplet, referenced as hsl(var(--name))
+}
+
+// lengthToken is a catalog token with a resolvable length value.
+type lengthToken struct {
+	token catalog.Token
+	px    float64
+}
+
+// tokenMatcher finds the catalog token nearest to a hardcoded value.
+type tokenMatcher struct {
+	hasColors bool // the catalog declares color tokens, resolvable or not
+	colors    []colorToken
+	lengths   map[string][]lengthToken // literalSpacing/literalRadius → tokens
+}
+
+// newTokenMatcher indexes the catalog's tokens. It returns nil when the
+// catalog declares no color, spacing, or radius tokens.
+func newTokenMatcher(tokens []catalog.Token) *tokenMatcher {
+	m := &tokenMatcher{lengths: make(map[string][]lengthToken)}
+
+	for _, t := range tokens {
+		if c, ok := parseColor(t.Value); ok {
+			m.hasColors = true
+			_, _, isFunc := splitColorFunc(strings.ToLower(strings.TrimSpace(t.Value)))
+			m.colors = append(m.colors, colorToken{
+				token:   t,
+				lab:     c.lab(),
+				triplet: !isFunc && !strings.HasPrefix(strings.TrimSpace(t.Value), "#"),
+			})
+			continue
+		}
+		if t.Category == "color" {
+			m.hasColors = true
+			continue
+		}
+
+		kind := ""
+		switch {
+		case t.Category == "radius" || strings.Contains(t.Name, "radius"):
+			kind = literalRadius
+		case t.Category == "spacing":
+			kind = literalSpacing
+		default:
+			continue
+		}
+		if px, ok := parseLength(t.Value); ok {
+			m.lengths[kind] = append(m.lengths[kind], lengthToken{token: t, px: px})
+		}
+	}
+
+	if !m.hasColors && len(m.lengths) == 0 {
+		return nil
+	}
+	return m
+}
+
+// nearestColor returns the color token closest to c and its CIEDE2000 distance.
+func (m *tokenMatcher) nearestColor(c rgbColor) (colorToken, float64, bool) {
+	lab := c.lab()
+	best, bestDist := colorToken{}, math.Inf(1)
+	for _, t := range m.colors {
+		if d := deltaE2000(lab, t.lab); d < bestDist {
+			best, bestDist = t, d
+		}
+	}
+	return best, bestDist, bestDist <= colorMatchThreshold
+}
+
+// nearestLength returns the token of the given kind closest to px.
+func (m *tokenMatcher) nearestLength(kind string, px float64) (lengthToken, bool) {
+	best, bestDiff := lengthToken{}, math.Inf(1)
+	for _, t := range m.lengths[kind] {
+		if d := math.Abs(t.px - px); d < bestDiff {
+			best, bestDiff = t, d
+		}
+	}
+	return best, bestDiff <= lengthMatchTolerance
+}
+
+// parseLength parses a px or rem length into pixels. Zero is not a length that
+// needs a token, so it is rejected.
+func parseLength(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(s, "px"):
+		s = s[:len(s)-2]
+	case strings.HasSuffix(s, "rem"):
+		s, scale = s[:len(s)-3], remPixels
+	default:
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v == 0 {
+		return 0, false
+	}
+	return math.Abs(v) * scale, true
+}
+
+// styleLiteral is a hardcoded value found in an inline style or className.
+type styleLiteral struct {
+	kind     string // literalColor, literalSpacing, or literalRadius
+	value    string // the literal as written, e.g. "#ef4444" or "13px"
+	property string // style property ("backgroundColor") or utility ("bg")
+	inClass  bool   // found in a className utility rather than a style object
+	negative bool   // negative utility such as "-mt-[4px]"
+	line     int    // 1-based
+	column   int    // 1-based
+}
+
+// collectStyleLiterals walks the AST and returns hardcoded colors and lengths
+// found in style={{...}} objects and className strings.
+func collectStyleLiterals(root *ts.Node, source []byte) []styleLiteral {
+	var result []styleLiteral
+	var walk func(node *ts.Node)
+	walk = func(node *ts.Node) {
+		if node.Kind() == "jsx_attribute" {
+			switch attributeName(node, source) {
+			case "className", "class":
+				forEachStringFragment(node, func(frag *ts.Node) {
+					result = append(result, classLiterals(frag.Utf8Text(source), frag.StartPosition())...)
+				})
+			case "style":
+				result = append(result, styleObjectLiterals(node, source)...)
+			}
+			return
+		}
+		for i := uint(0); i < uint(node.ChildCount()); i++ {
+			walk(node.Child(i))
+		}
+	}
+	walk(root)
+	return result
+}
+
+// attributeName returns the name of a jsx_attribute node.
+func attributeName(node *ts.Node, source []byte) string {
+	for i := uint(0); i < uint(node.ChildCount()); i++ {
+		if child := node.Child(i); child.Kind() == "property_identifier" {
+			return child.Utf8Text(source)
+		}
+	}
+	return ""
+}
+
+// forEachStringFragment calls fn for every string_fragment below node.
+func forEachStringFragment(node *ts.Node, fn func(*ts.Node)) {
+	if node.Kind() == "string_fragment" {
+		fn(node)
+		return
+	}
+	for i := uint(0); i < uint(node.ChildCount()); i++ {
+		forEachStringFragment(node.Child(i), fn)
+	}
+}
+
+// styleObjectLiterals extracts literals from the pairs of a style={{...}} object.
+func styleObjectLiterals(attr *ts.Node, source []byte) []styleLiteral {
+	var result []styleLiteral
+	var walk func(node *ts.Node)
+	walk = func(node *ts.Node) {
+		if node.Kind() != "pair" {
+			for i := uint(0); i < uint(node.ChildCount()); i++ {
+				walk(node.Child(i))
+			}
+			return
+		}
+
+		key, value := node.ChildByFieldName("key"), node.ChildByFieldName("value")
+		if key == nil || value == nil {
+			return
+		}
+		prop := key.Utf8Text(source)
+		if key.Kind() == "string" {
+			prop = extractStringContent(key, source)
+		}
+
+		kind := ""
+		switch {
+		case spacingStyleProps[prop]:
+			kind = literalSpacing
+		case radiusStyleProps[prop]:
+			kind = literalRadius
+		}
+
+		if value.Kind() == "number" {
+			// React treats unitless numbers as pixels for these properties.
+			if kind != "" {
+				result = append(result, styleLiteral{
+					kind:     kind,
+					value:    value.Utf8Text(source) + "px",
+					property: prop,
+					line:     int(value.StartPosition().Row) + 1,
+					column:   int(value.StartPosition().Column) + 1,
+				})
+			}
+			return
+		}
+
+		forEachStringFragment(value, func(frag *ts.Node) {
+			text := frag.Utf8Text(source)
+			add := func(kind string, loc []int) {
+				line, column := offsetPosition(text, loc[0], frag.StartPosition())
+				result = append(result, styleLiteral{
+					kind:     kind,
+					value:    text[loc[0]:loc[1]],
+					property: prop,
+					line:     line,
+					column:   column,
+				})
+			}
+			for _, loc := range colorLiteralRE.FindAllStringIndex(text, -1) {
+				add(literalColor, loc)
+			}
+			if kind != "" {
+				for _, loc := range lengthLiteralRE.FindAllStringIndex(text, -1) {
+					add(kind, loc)
+				}
+			}
+		})
+	}
+	walk(attr)
+	return result
+}
+
+// classLiterals extracts hardcoded values from Tailwind arbitrary-value
+// utilities such as "bg-[#ef4444]" or "hover:p-[13px]" in a class list.
+func classLiterals(text string, start ts.Point) []styleLiteral {
+	var result []styleLiteral
+	for offset := 0; offset < len(text); {
+		// Skip whitespace, then take the next class.
+		if text[offset] == ' ' || text[offset] == '\t' || text[offset] == '\n' || text[offset] == '\r' {
+			offset++
+			continue
+		}
+		end := offset
+		for end < len(text) && text[end] != ' ' && text[end] != '\t' && text[end] != '\n' && text[end] != '\r' {
+			end++
+		}
+		class := text[offset:end]
+
+		if utility, value, negative, ok := parseArbitraryClass(class); ok {
+			kind := ""
+			switch {
+			case spacingUtilities[utility]:
+				kind = literalSpacing
+			case radiusUtilities[utility]:
+				kind = literalRadius
+			}
+			if _, isColor := parseColor(value); isColor {
+				kind = literalColor
+			}
+			if kind != "" {
+				line, column := offsetPosition(text, offset, start)
+				result = append(result, styleLiteral{
+					kind:     kind,
+					value:    value,
+					property: utility,
+					inClass:  true,
+					negative: negative,
+					line:     line,
+					column:   column,
+				})
+			}
+		}
+		offset = end
+	}
+	return result
+}
+
+// parseArbitraryClass splits a Tailwind arbitrary-value class into its utility
+// and value, stripping variants, "!" and "-" prefixes, type hints, and opacity
+// modifiers: "hover:!-mt-[4px]" → ("mt", "4px", true).
+func parseArbitraryClass(class string) (utility, value string, negative, ok bool) {
+	open := strings.Index(class, "-[")
+	closeIdx := strings.LastIndex(class, "]")
+	if open < 0 || closeIdx < open {
+		return "", "", false, false
+	}
+
+	utility = class[:open]
+	if i := strings.LastIndex(utility, ":"); i >= 0 {
+		utility = utility[i+1:]
+	}
+	utility = strings.TrimPrefix(utility, "!")
+	if strings.HasPrefix(utility, "-") {
+		utility, negative = utility[1:], true
+	}
+
+	value = strings.ReplaceAll(class[open+2:closeIdx], "_", " ")
+	for _, hint := range []string{"color:", "length:"} {
+		value = strings.TrimPrefix(value, hint)
+	}
+	return utility, value, negative, utility != "" && value != ""
+}
+
+// offsetPosition converts a byte offset within a node's text to a 1-based
+// line and column in the source.
+func offsetPosition(text string, offset int, start ts.Point) (line, column int) {
+	line = int(start.Row) + 1
+	column = int(start.Column) + 1
+	for i := 0; i < offset; i++ {
+		if text[i] == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+// tokensRule flags hardcoded colors and lengths that should use design tokens.
+type tokensRule struct{}
+
+func (tokensRule) Info() []RuleInfo {
+	return []RuleInfo{
+		{ID: "no-inline-styles-for-tokens", Description: "Hardcoded color or spacing value should use a design token", Severity: "warning"},
+	}
+}
+
+func (tokensRule) Check(page *Page) []Violation {
+	if page.tokens == nil {
+		return nil
+	}
+	return page.tokens.check(page.Tree.RootNode(), page.Source)
+}
+
+// check flags hardcoded colors and lengths that should use design tokens.
+func (m *tokenMatcher) check(root *ts.Node, source []byte) []Violation {
+	var violations []Violation
+	for _, lit := range collectStyleLiterals(root, source) {
+		var message, suggestion string
+
+		switch lit.kind {
+		case literalColor:
+			c, ok := parseColor(lit.value)
+			if !ok || !m.hasColors {
+				continue
+			}
+			message = fmt.Sprintf("Hardcoded color %q should use a design token", lit.value)
+			suggestion = "Use a color token from the catalog"
+			if t, dist, near := m.nearestColor(c); near {
+				suggestion = fmt.Sprintf("Use %s (token %q, ΔE %.1f)", colorTokenRef(lit, t), t.token.Name, dist)
+			}
+
+		case literalSpacing, literalRadius:
+			px, ok := parseLength(lit.value)
+			if !ok || len(m.lengths[lit.kind]) == 0 {
+				continue
+			}
+			message = fmt.Sprintf("Hardcoded %s %q should use a design token", lit.kind, lit.value)
+			suggestion = fmt.Sprintf("Use a %s token from the catalog", lit.kind)
+			if t, near := m.nearestLength(lit.kind, px); near {
+				suggestion = fmt.Sprintf("Use %s (token %q = %s)", lengthTokenRef(lit, t), t.token.Name, t.token.Value)
+			}
+		}
+
+		violations = append(violations, Violation{
+			Rule:       "no-inline-styles-for-tokens",
+			Message:    message,
+			Severity:   "warning",
+			Line:       lit.line,
+			Column:     lit.column,
+			Suggestion: suggestion,
+		})
+	}
+	return violations
+}
+
+// colorTokenRef returns how to reference a color token where the literal was
+// found: a utility class ("bg-destructive") or a CSS variable.
+func colorTokenRef(lit styleLiteral, t colorToken) string {
+	if lit.inClass {
+		return utilityRef(lit, t.token.Name, "color-")
+	}
+	if t.triplet {
+		return fmt.Sprintf("hsl(var(--%s))", t.token.Name)
+	}
+	return fmt.Sprintf("var(--%s)", t.token.Name)
+}
+
+// lengthTokenRef returns how to reference a length token where the literal
+// was found: a utility class ("p-3") or a CSS variable.
+func lengthTokenRef(lit styleLiteral, t lengthToken) string {
+	if !lit.inClass {
+		return fmt.Sprintf("var(--%s)", t.token.Name)
+	}
+	return utilityRef(lit, t.token.Name, lit.kind+"-")
+}
+
+// utilityRef builds the Tailwind class for a token: the token name without its
+// theme namespace ("color-primary" → "bg-primary"), or an arbitrary var()
+// reference when the name is the namespace itself ("radius" → "rounded-[var(--radius)]").
+func utilityRef(lit styleLiteral, name, namespace string) string {
+	prefix := lit.property
+	if lit.negative {
+		prefix = "-" + prefix
+	}
+	suffix := strings.TrimPrefix(name, namespace)
+	if suffix == "" || suffix == strings.TrimSuffix(namespace, "-") {
+		return fmt.Sprintf("%s-[var(--%s)]", prefix, name)
+	}
+	return prefix + "-" + suffix
+}
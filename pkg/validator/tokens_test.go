@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ts "github.com/tree-sitter/go-tree-sitter"
+
+	"github.com/gnana997/uispec/pkg/catalog"
+)
+
+func testTokens() []catalog.Token {
+	return []catalog.Token{
+		{Name: "destructive", Value: "#ef4444", Category: "color"},
+		{Name: "primary", Value: "222.2 47.4% 11.2%", Category: "color"},
+		{Name: "muted", Value: "hsl(var(--muted))", Category: "color"},
+		{Name: "spacing-3", Value: "0.75rem", Category: "spacing"},
+		{Name: "radius", Value: "0.625rem", Category: "border"},
+	}
+}
+
+func TestNewTokenMatcher(t *testing.T) {
+	m := newTokenMatcher(testTokens())
+	require.NotNil(t, m)
+
+	assert.True(t, m.hasColors)
+	require.Len(t, m.colors, 2)
+	assert.False(t, m.colors[0].triplet)
+	assert.True(t, m.colors[1].triplet)
+	require.Len(t, m.lengths[literalSpacing], 1)
+	assert.Equal(t, 12.0, m.lengths[literalSpacing][0].px)
+	require.Len(t, m.lengths[literalRadius], 1)
+
+	assert.Nil(t, newTokenMatcher([]catalog.Token{{Name: "font-sans", Value: "Inter", Category: "typography"}}))
+}
+
+func TestTokenMatcher_Nearest(t *testing.T) {
+	m := newTokenMatcher(testTokens())
+
+	c, _ := parseColor("#ee4343")
+	tok, dist, near := m.nearestColor(c)
+	assert.True(t, near)
+	assert.Equal(t, "destructive", tok.token.Name)
+	assert.Less(t, dist, 1.0)
+
+	c, _ = parseColor("#22c55e")
+	_, _, near = m.nearestColor(c)
+	assert.False(t, near, "green should not match red or navy")
+
+	lt, near := m.nearestLength(literalSpacing, 13)
+	assert.True(t, near)
+	assert.Equal(t, "spacing-3", lt.token.Name)
+	_, near = m.nearestLength(literalSpacing, 40)
+	assert.False(t, near)
+}
+
+func TestParseArbitraryClass(t *testing.T) {
+	tests := []struct {
+		class    string
+		utility  string
+		value    string
+		negative bool
+		ok       bool
+	}{
+		{"bg-[#ef4444]", "bg", "#ef4444", false, true},
+		{"hover:!-mt-[4px]", "mt", "4px", true, true},
+		{"text-[color:rgb(1_2_3)]/50", "text", "rgb(1 2 3)", false, true},
+		{"md:p-4", "", "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.class, func(t *testing.T) {
+			utility, value, negative, ok := parseArbitraryClass(tt.class)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.utility, utility)
+				assert.Equal(t, tt.value, value)
+				assert.Equal(t, tt.negative, negative)
+			}
+		})
+	}
+}
+
+func TestClassLiterals(t *testing.T) {
+	lits := classLiterals("flex p-[13px]\n  bg-[#ef4444] text-[14px]", ts.Point{Row: 4, Column: 20})
+
+	require.Len(t, lits, 2)
+	assert.Equal(t, literalSpacing, lits[0].kind)
+	assert.Equal(t, "p", lits[0].property)
+	assert.Equal(t, 5, lits[0].line)
+	assert.Equal(t, 26, lits[0].column)
+	assert.Equal(t, literalColor, lits[1].kind)
+	assert.Equal(t, 6, lits[1].line)
+	assert.Equal(t, 3, lits[1].column)
+}
+
+func TestUtilityRef(t *testing.T) {
+	assert.Equal(t, "bg-destructive", utilityRef(styleLiteral{property: "bg"}, "destructive", "color-"))
+	assert.Equal(t, "text-primary", utilityRef(styleLiteral{property: "text"}, "color-primary", "color-"))
+	assert.Equal(t, "-mt-3", utilityRef(styleLiteral{property: "mt", negative: true}, "spacing-3", "spacing-"))
+	assert.Equal(t, "rounded-[var(--radius)]", utilityRef(styleLiteral{property: "rounded"}, "radius", "radius-"))
+}
+
+func TestValidatePage_HardcodedTokens(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+	v.tokens = newTokenMatcher(testTokens())
+
+	code := `
+export default function Page() {
+  return (
+    <div
+      style={{ color: "#ef4444", padding: 12, borderRadius: "10px", width: 300 }}
+      className="flex bg-[#0f172a] p-[13px] text-[14px]"
+    >
+      Hi
+    </div>
+  )
+}
+`
+	result := v.ValidatePage(code, false)
+
+	require.Len(t, result.Violations, 5)
+	for _, violation := range result.Violations {
+		assert.Equal(t, "no-inline-styles-for-tokens", violation.Rule)
+	}
+	assert.Contains(t, result.Violations[0].Suggestion, `var(--destructive)`)
+	assert.Contains(t, result.Violations[1].Suggestion, `var(--spacing-3)`)
+	assert.Contains(t, result.Violations[2].Suggestion, `var(--radius)`)
+	assert.Contains(t, result.Violations[3].Suggestion, `bg-primary`)
+	assert.Contains(t, result.Violations[4].Suggestion, `p-3`)
+	assert.Equal(t, 6, result.Violations[3].Line)
+}
+
+func TestValidatePage_NoTokensNoViolations(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `export default function Page() { return <div style={{ color: "#ef4444" }} className="p-[13px]" /> }`
+	result := v.ValidatePage(code, false)
+	assert.Empty(t, result.Violations)
+}
@@ -2,10 +2,12 @@ package validator
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/gnana997/uispec/pkg/catalog"
 	"github.com/gnana997/uispec/pkg/parser"
+	"github.com/gnana997/uispec/pkg/textdiff"
 )
 
 // Validator checks source code against the design system catalog.
@@ -13,14 +15,53 @@ type Validator struct {
 	catalog *catalog.Catalog
 	index   *catalog.CatalogIndex
 	parser  *parser.ParserManager
+	rules   *RuleConfig   // optional severity overrides
+	tokens  *tokenMatcher // nil when the catalog has no checkable tokens
+
+	// transparent components are skipped when resolving a usage's parent,
+	// so wrappers like <Fragment> or a project's layout components don't
+	// break composition rules.
+	transparent map[string]bool
+}
+
+// defaultTransparentComponents are always treated as transparent wrappers.
+var defaultTransparentComponents = []string{"Fragment", "React.Fragment"}
+
+// ValidateOptions controls a single validation run.
+type ValidateOptions struct {
+	// AutoFix generates and applies deterministic fixes.
+	AutoFix bool
+	// Filename is the page's path relative to the project root. It is used to
+	// match per-file rule overrides and to pick the grammar, and may be empty.
+	Filename string
+	// Language overrides the grammar chosen from Filename with a source
+	// extension such as "jsx" or "ts", or "md"/"mdx" for a document. Pages
+	// with neither are parsed as TSX.
+	Language string
+	// FixFormat selects how fixed code is returned: FixFormatCode (the
+	// default) fills FixedCode, FixFormatDiff fills FixDiff instead.
+	FixFormat string
+	// Fix, if set, chooses which violations AutoFix fixes; the others are
+	// still reported.
+	Fix func(Violation) bool
 }
 
+// Fix formats for ValidateOptions.FixFormat.
+const (
+	FixFormatCode = "code"
+	FixFormatDiff = "diff"
+)
+
+// defaultDiffName names the page in FixDiff headers when no Filename is set.
+const defaultDiffName = "page.tsx"
+
 // ValidationResult represents the result of validating a page of code.
 type ValidationResult struct {
 	Valid      bool        `json:"valid"`
 	Violations []Violation `json:"violations"`
 	Fixes      []AutoFix   `json:"fixes,omitempty"`
 	FixedCode  string      `json:"fixed_code,omitempty"`
+	FixDiff    string      `json:"fix_diff,omitempty"`
 	Summary    string      `json:"summary"`
 }
 
@@ -31,152 +72,376 @@ type Violation struct {
 	Severity   string `json:"severity"` // "error", "warning", "info"
 	Line       int    `json:"line"`
 	Column     int    `json:"column"`
+	EndLine    int    `json:"end_line,omitempty"` // last line of the element, when it spans several
 	Component  string `json:"component,omitempty"`
 	Suggestion string `json:"suggestion,omitempty"`
+
+	// Edits, set by custom rules, fix the violation when applied together.
+	// They are reported in ValidationResult.Fixes.
+	Edits []Edit `json:"-"`
+
+	// Context for fixes.
+	usage       *JSXUsage   // offending element
+	prop        string      // offending prop of usage, if any
+	value       string      // offending value of prop, if any
+	imp         *ImportInfo // offending import statement, if any
+	replacement string      // name or value the fix puts in, when a fix is safe
 }
 
 // NewValidator creates a validator backed by the given catalog and parser.
 func NewValidator(cat *catalog.Catalog, idx *catalog.CatalogIndex, pm *parser.ParserManager) *Validator {
-	return &Validator{
+	v := &Validator{
 		catalog: cat,
 		index:   idx,
 		parser:  pm,
 	}
+	v.SetTransparentComponents(nil)
+	if cat != nil {
+		v.tokens = newTokenMatcher(cat.Tokens)
+	}
+	return v
+}
+
+// SetRuleConfig installs rule severity overrides. It must be called before the
+// validator is used concurrently; pass nil to restore the built-in severities.
+func (v *Validator) SetRuleConfig(cfg *RuleConfig) {
+	v.rules = cfg
+}
+
+// SetTransparentComponents sets the wrapper components, in addition to
+// Fragment, that composition rules look through when finding a usage's
+// parent. Like SetRuleConfig, it must be called before concurrent use.
+func (v *Validator) SetTransparentComponents(names []string) {
+	v.transparent = make(map[string]bool, len(defaultTransparentComponents)+len(names))
+	for _, name := range defaultTransparentComponents {
+		v.transparent[name] = true
+	}
+	for _, name := range names {
+		v.transparent[name] = true
+	}
 }
 
 // ValidatePage parses TSX code and validates component usages against the catalog.
 // If autoFix is true, deterministic fixes are generated and applied.
 func (v *Validator) ValidatePage(code string, autoFix bool) *ValidationResult {
+	return v.ValidatePageWithOptions(code, ValidateOptions{AutoFix: autoFix})
+}
+
+// ValidatePageWithOptions is ValidatePage with per-call options such as the
+// page filename used for rule overrides and grammar selection. Markdown and
+// MDX documents (.md, .mdx, or a "md"/"mdx" language hint) have their JSX code
+// blocks validated instead.
+func (v *Validator) ValidatePageWithOptions(code string, opts ValidateOptions) *ValidationResult {
+	if doc, mdx := documentKind(opts.Language, opts.Filename); doc {
+		return v.validateDocument(code, mdx, opts)
+	}
 	source := []byte(code)
 
-	// Parse as TSX.
-	tree, err := v.parser.Parse(source, parser.LanguageTypeScript, true)
+	g, err := pageGrammar(opts.Language, opts.Filename)
 	if err != nil {
-		return &ValidationResult{
-			Valid:   false,
-			Summary: fmt.Sprintf("parse error: %v", err),
-			Violations: []Violation{{
-				Rule:     "parse-error",
-				Message:  fmt.Sprintf("Failed to parse TSX: %v", err),
-				Severity: "error",
-				Line:     1,
-				Column:   1,
-			}},
-		}
+		return parseErrorResult(err, fmt.Sprintf("Cannot validate page: %v", err))
+	}
+	tree, err := v.parse(source, g)
+	if err != nil {
+		return parseErrorResult(err, fmt.Sprintf("Failed to parse %s: %v", g, err))
 	}
 	defer tree.Close()
 
 	// Extract JSX usages and imports.
 	extraction := ExtractJSX(tree, source)
+	v.resolveDefaultImports(extraction)
+	v.resolveSubComponentAliases(extraction)
+	v.skipTransparentAncestors(extraction)
+
+	page := &Page{
+		Filename:   opts.Filename,
+		Source:     source,
+		Tree:       tree,
+		Extraction: extraction,
+		Catalog:    v.catalog,
+		Index:      v.index,
+		bindings:   extraction.bindings(),
+		tokens:     v.tokens,
+	}
+
+	var violations []Violation
+	for _, check := range checks() {
+		violations = append(violations, runCheck(check, page)...)
+	}
+	setEndLines(violations, code)
+
+	// Drop violations silenced by uispec-disable comments.
+	violations = applySuppressions(violations, collectSuppressions(tree.RootNode(), source))
+
+	// Apply configured rule severities.
+	violations = v.rules.apply(violations, opts.Filename)
+
+	// Report in source order, whichever rule found each violation.
+	sortViolations(violations, nil)
+
+	result := &ValidationResult{
+		Valid:      len(filterBySeverity(violations, "error")) == 0,
+		Violations: violations,
+		Summary:    buildSummary(violations),
+	}
 
-	// Build import lookup: component name → source path.
-	importedNames := make(map[string]string)  // name → source
-	importSources := make(map[string][]string) // source → names
-	for _, imp := range extraction.Imports {
-		for _, name := range imp.Names {
-			importedNames[name] = imp.Source
+	if opts.AutoFix && len(violations) > 0 {
+		fixes, fixedCode := v.generateFixes(code, g, violations, opts.Fix, extraction.Imports, !tree.RootNode().HasError())
+		if len(fixes) > 0 {
+			result.Fixes = fixes
+			if opts.FixFormat == FixFormatDiff {
+				name := opts.Filename
+				if name == "" {
+					name = defaultDiffName
+				}
+				result.FixDiff = textdiff.Unified(name, name, code, fixedCode)
+			} else {
+				result.FixedCode = fixedCode
+			}
+		}
+	}
+
+	return result
+}
+
+// setEndLines sets EndLine on violations about an element that spans several
+// lines of code.
+func setEndLines(violations []Violation, code string) {
+	for i := range violations {
+		u := violations[i].usage
+		if u == nil || u.span.empty() {
+			continue
+		}
+		if end, _ := position(code, u.span.end-1); end > violations[i].Line {
+			violations[i].EndLine = end
+		}
+	}
+}
+
+// parseErrorResult is the result for a page that could not be parsed.
+func parseErrorResult(err error, message string) *ValidationResult {
+	return &ValidationResult{
+		Valid:   false,
+		Summary: fmt.Sprintf("parse error: %v", err),
+		Violations: []Violation{{
+			Rule:     parseErrorRule.ID,
+			Message:  message,
+			Severity: "error",
+			Line:     1,
+			Column:   1,
+		}},
+	}
+}
+
+// runCheck runs one rule and gives violations without a severity their
+// rule's default.
+func runCheck(check Rule, page *Page) []Violation {
+	violations := check.Check(page)
+	for i := range violations {
+		if violations[i].Severity == "" {
+			violations[i].Severity = "warning"
+			if info, ok := LookupRule(violations[i].Rule); ok {
+				violations[i].Severity = info.Severity
+			}
 		}
-		if imp.DefaultName != "" {
-			importedNames[imp.DefaultName] = imp.Source
+	}
+	return violations
+}
+
+// resolveDefaultImports rewrites tags bound by a default import, such as
+// <Btn> after `import Btn from "@/components/ui/button"`, to the catalog
+// component imported from that path. A path shared by several components
+// doesn't say which one is the default export, so its tags are left as
+// written and reported as unknown components.
+func (v *Validator) resolveDefaultImports(extraction *JSXExtraction) {
+	defaults := make(map[string]string) // local name → component
+	var byPath map[string][]string
+	for local, b := range extraction.bindings() {
+		if b.exported != "default" {
+			continue
+		}
+		if byPath == nil {
+			byPath = make(map[string][]string)
+			for name, comp := range v.index.ComponentByName {
+				byPath[comp.ImportPath] = append(byPath[comp.ImportPath], name)
+			}
+		}
+		if names := byPath[b.source]; len(names) == 1 && names[0] != local {
+			defaults[local] = names[0]
 		}
-		importSources[imp.Source] = append(importSources[imp.Source], imp.Names...)
+	}
+	if len(defaults) == 0 {
+		return
 	}
 
-	// Track which components appear as children of which parents.
-	childrenOf := make(map[string]map[string]bool) // parent → set of child component names
-	for _, usage := range extraction.Usages {
-		if usage.ParentComponent != "" {
-			if childrenOf[usage.ParentComponent] == nil {
-				childrenOf[usage.ParentComponent] = make(map[string]bool)
+	resolve := func(tag string) string {
+		root, member, hasMember := strings.Cut(tag, ".")
+		name, ok := defaults[root]
+		switch {
+		case !ok:
+			return tag
+		case hasMember:
+			return name + "." + member
+		}
+		return name
+	}
+	for _, usages := range [][]JSXUsage{extraction.Usages, extraction.Intrinsics} {
+		for i := range usages {
+			usage := &usages[i]
+			if resolved := resolve(usage.ComponentName); resolved != usage.ComponentName {
+				usage.LocalName = usage.ComponentName
+				usage.ComponentName = resolved
+			}
+			usage.ParentComponent = resolve(usage.ParentComponent)
+			for j, ancestor := range usage.Ancestors {
+				usage.Ancestors[j] = resolve(ancestor)
 			}
-			childrenOf[usage.ParentComponent][usage.ComponentName] = true
 		}
 	}
+}
 
-	var violations []Violation
+// resolveSubComponentAliases rewrites dotted sub-component tags such as
+// <Dialog.Trigger> to their catalog names so that every check treats them
+// exactly like <DialogTrigger>.
+func (v *Validator) resolveSubComponentAliases(extraction *JSXExtraction) {
+	if len(v.index.SubComponentByAlias) == 0 {
+		return
+	}
+	for _, usages := range [][]JSXUsage{extraction.Usages, extraction.Intrinsics} {
+		for i := range usages {
+			usage := &usages[i]
+			if name, ok := v.index.SubComponentByAlias[usage.ComponentName]; ok {
+				if usage.LocalName == "" {
+					usage.LocalName = usage.ComponentName
+				}
+				usage.ComponentName = name
+			}
+			if name, ok := v.index.SubComponentByAlias[usage.ParentComponent]; ok {
+				usage.ParentComponent = name
+			}
+			for j, ancestor := range usage.Ancestors {
+				if name, ok := v.index.SubComponentByAlias[ancestor]; ok {
+					usage.Ancestors[j] = name
+				}
+			}
+		}
+	}
+}
+
+// skipTransparentAncestors drops transparent wrappers from each usage's
+// ancestors and sets ParentComponent to the nearest remaining one.
+func (v *Validator) skipTransparentAncestors(extraction *JSXExtraction) {
+	for _, usages := range [][]JSXUsage{extraction.Usages, extraction.Intrinsics} {
+		for i := range usages {
+			usage := &usages[i]
+			var kept []string
+			for _, ancestor := range usage.Ancestors {
+				if !v.transparent[ancestor] {
+					kept = append(kept, ancestor)
+				}
+			}
+			usage.Ancestors = kept
+			usage.ParentComponent = currentParent(kept)
+		}
+	}
+}
+
+// componentRule flags components that are not in the catalog or are deprecated.
+type componentRule struct{}
+
+func (componentRule) Info() []RuleInfo {
+	return []RuleInfo{
+		{ID: "unknown-component", Description: "Component is not defined in the catalog", Severity: "warning"},
+		{ID: "deprecated-component", Description: "Component is marked deprecated in the catalog", Severity: "warning"},
+	}
+}
 
-	for _, usage := range extraction.Usages {
-		comp, isTopLevel := v.index.ComponentByName[usage.ComponentName]
-		_, isSubComponent := v.index.SubComponentByName[usage.ComponentName]
+func (componentRule) Check(page *Page) []Violation {
+	var violations []Violation
 
-		if !isTopLevel && !isSubComponent {
+	for _, usage := range page.Extraction.Usages {
+		if !page.isCatalogComponent(usage.ComponentName) {
 			// Unknown component — only warn, could be a custom component.
-			violations = append(violations, Violation{
+			violation := Violation{
 				Rule:      "unknown-component",
 				Message:   fmt.Sprintf("Component %q is not in the catalog", usage.ComponentName),
 				Severity:  "warning",
 				Line:      usage.Line,
 				Column:    usage.Column,
 				Component: usage.ComponentName,
-			})
+			}
+			if matches := suggestMatches(usage.ComponentName, page.componentNames()); len(matches) > 0 {
+				violation.Suggestion = didYouMean("<%s>", matches)
+				// Only rename tags that nothing in the file defines; an
+				// imported or declared component is not a typo.
+				_, imported := page.bindings[usage.bindingName()]
+				if len(matches) == 1 && !imported && usage.LocalName == "" && !page.Extraction.declared[usage.ComponentName] {
+					violation.replacement = matches[0]
+					violation.usage = &usage
+				}
+			}
+			violations = append(violations, violation)
 			continue
 		}
 
-		// Determine the catalog component for this usage.
-		var catalogComp *catalog.Component
-		if isTopLevel {
-			catalogComp = comp
-		} else {
-			catalogComp = v.index.SubComponentByName[usage.ComponentName]
-		}
-
-		// Check deprecated component.
-		if isTopLevel && catalogComp.Deprecated {
-			msg := fmt.Sprintf("Component %q is deprecated", usage.ComponentName)
-			if catalogComp.DeprecatedMsg != "" {
-				msg += ": " + catalogComp.DeprecatedMsg
-			}
-			violations = append(violations, Violation{
-				Rule:      "deprecated-component",
-				Message:   msg,
-				Severity:  "warning",
-				Line:      usage.Line,
-				Column:    usage.Column,
-				Component: usage.ComponentName,
-			})
+		comp, isTopLevel := page.Index.ComponentByName[usage.ComponentName]
+		if !isTopLevel || !comp.Deprecated {
+			continue
 		}
-
-		// Check import (only for top-level components).
-		if isTopLevel {
-			violations = append(violations, v.checkImport(usage, catalogComp, importedNames)...)
+		msg := fmt.Sprintf("Component %q is deprecated", usage.ComponentName)
+		if comp.DeprecatedMsg != "" {
+			msg += ": " + comp.DeprecatedMsg
 		}
-
-		// Check props (only for top-level components with defined props).
-		if isTopLevel {
-			violations = append(violations, v.checkProps(usage, catalogComp)...)
+		violation := Violation{
+			Rule:      "deprecated-component",
+			Message:   msg,
+			Severity:  "warning",
+			Line:      usage.Line,
+			Column:    usage.Column,
+			Component: usage.ComponentName,
 		}
-
-		// Check composition rules (for sub-components).
-		if isSubComponent {
-			violations = append(violations, v.checkComposition(usage)...)
+		if comp.ReplacedBy != "" {
+			violation.Suggestion = fmt.Sprintf("Use <%s> instead", comp.ReplacedBy)
+			// An aliased or namespaced tag can't simply be renamed.
+			if usage.LocalName == "" {
+				violation.replacement = comp.ReplacedBy
+				violation.usage = &usage
+			}
 		}
+		violations = append(violations, violation)
 	}
 
-	// Check must_contain rules.
-	violations = append(violations, v.checkMustContain(extraction.Usages, childrenOf)...)
+	return violations
+}
 
-	result := &ValidationResult{
-		Valid:      len(filterBySeverity(violations, "error")) == 0,
-		Violations: violations,
-		Summary:    buildSummary(violations),
+// importRule checks that catalog components are imported from their catalog
+// import path.
+type importRule struct{}
+
+func (importRule) Info() []RuleInfo {
+	return []RuleInfo{
+		{ID: "missing-import", Description: "Catalog component is used without being imported", Severity: "error"},
+		{ID: "wrong-import-path", Description: "Catalog component is imported from a path other than its catalog import_path", Severity: "error"},
 	}
+}
 
-	if autoFix && len(violations) > 0 {
-		fixes, fixedCode := GenerateFixes(code, violations, v.index)
-		if len(fixes) > 0 {
-			result.Fixes = fixes
-			result.FixedCode = fixedCode
+func (importRule) Check(page *Page) []Violation {
+	var violations []Violation
+	for _, usage := range page.Extraction.Usages {
+		// Sub-components are imported with their parent's path, so only
+		// top-level components are checked.
+		if comp, ok := page.Index.ComponentByName[usage.ComponentName]; ok {
+			violations = append(violations, checkImport(usage, comp, page.bindings)...)
 		}
 	}
-
-	return result
+	return violations
 }
 
 // checkImport validates that the component is properly imported.
-func (v *Validator) checkImport(usage JSXUsage, comp *catalog.Component, importedNames map[string]string) []Violation {
+func checkImport(usage JSXUsage, comp *catalog.Component, bindings map[string]importBinding) []Violation {
 	var violations []Violation
 
-	source, imported := importedNames[usage.ComponentName]
+	binding, imported := bindings[usage.bindingName()]
 	if !imported {
 		violations = append(violations, Violation{
 			Rule:       "missing-import",
@@ -187,33 +452,64 @@ func (v *Validator) checkImport(usage JSXUsage, comp *catalog.Component, importe
 			Component:  usage.ComponentName,
 			Suggestion: fmt.Sprintf("Add: import { %s } from %q", usage.ComponentName, comp.ImportPath),
 		})
-	} else if source != comp.ImportPath {
+	} else if binding.source != comp.ImportPath {
 		violations = append(violations, Violation{
 			Rule:       "wrong-import-path",
-			Message:    fmt.Sprintf("Component %q is imported from %q but should be from %q", usage.ComponentName, source, comp.ImportPath),
+			Message:    fmt.Sprintf("Component %q is imported from %q but should be from %q", usage.ComponentName, binding.source, comp.ImportPath),
 			Severity:   "error",
 			Line:       usage.Line,
 			Column:     usage.Column,
 			Component:  usage.ComponentName,
 			Suggestion: fmt.Sprintf("Change import path to %q", comp.ImportPath),
+			usage:      &usage,
+			imp:        binding.imp,
 		})
 	}
 
 	return violations
 }
 
-// checkProps validates component props against the catalog.
-func (v *Validator) checkProps(usage JSXUsage, comp *catalog.Component) []Violation {
+// propsRule checks props against the catalog's prop definitions.
+type propsRule struct{}
+
+func (propsRule) Info() []RuleInfo {
+	return []RuleInfo{
+		{ID: "missing-required-prop", Description: "A required prop is not provided", Severity: "error"},
+		{ID: "unknown-prop", Description: "Prop is not defined for the component in the catalog", Severity: "info"},
+		{ID: "deprecated-prop", Description: "Prop is marked deprecated in the catalog", Severity: "warning"},
+		{ID: "prop-type-mismatch", Description: "Prop value does not match the prop's catalog type", Severity: "error"},
+		{ID: "invalid-prop-value", Description: "Prop value is not one of the catalog's allowed values", Severity: "warning"},
+	}
+}
+
+func (propsRule) Check(page *Page) []Violation {
+	var violations []Violation
+	for _, usage := range page.Extraction.Usages {
+		// Sub-components are only checked when the catalog defines their
+		// props, since many catalogs leave them undocumented.
+		if comp, ok := page.Index.ComponentByName[usage.ComponentName]; ok {
+			violations = append(violations, checkProps(usage, comp.Props)...)
+		} else if subDef, ok := page.Index.SubComponentDef[usage.ComponentName]; ok && len(subDef.Props) > 0 {
+			violations = append(violations, checkProps(usage, subDef.Props)...)
+		}
+	}
+	return violations
+}
+
+// checkProps validates component or sub-component props against the catalog.
+func checkProps(usage JSXUsage, props []catalog.Prop) []Violation {
 	var violations []Violation
 
 	// Build prop lookup.
-	propDefs := make(map[string]*catalog.Prop, len(comp.Props))
-	for i := range comp.Props {
-		propDefs[comp.Props[i].Name] = &comp.Props[i]
+	propDefs := make(map[string]*catalog.Prop, len(props))
+	propNames := make([]string, len(props))
+	for i := range props {
+		propDefs[props[i].Name] = &props[i]
+		propNames[i] = props[i].Name
 	}
 
 	// Check for missing required props.
-	for _, prop := range comp.Props {
+	for _, prop := range props {
 		if prop.Required {
 			if _, provided := usage.Props[prop.Name]; !provided {
 				suggestion := ""
@@ -228,6 +524,8 @@ func (v *Validator) checkProps(usage JSXUsage, comp *catalog.Component) []Violat
 					Column:     usage.Column,
 					Component:  usage.ComponentName,
 					Suggestion: suggestion,
+					usage:      &usage,
+					prop:       prop.Name,
 				})
 			}
 		}
@@ -241,52 +539,96 @@ func (v *Validator) checkProps(usage JSXUsage, comp *catalog.Component) []Violat
 
 		def, known := propDefs[propName]
 		if !known {
-			violations = append(violations, Violation{
+			violation := Violation{
 				Rule:      "unknown-prop",
 				Message:   fmt.Sprintf("Prop %q is not defined for component %q", propName, usage.ComponentName),
 				Severity:  "info",
 				Line:      usage.Line,
 				Column:    usage.Column,
 				Component: usage.ComponentName,
-			})
+			}
+			if matches := suggestMatches(propName, propNames); len(matches) > 0 {
+				violation.Suggestion = didYouMean("%q", matches)
+				// Renaming must not duplicate a prop that is already set.
+				if _, set := usage.Props[matches[0]]; len(matches) == 1 && !set {
+					violation.replacement = matches[0]
+					violation.usage = &usage
+					violation.prop = propName
+				}
+			}
+			violations = append(violations, violation)
 			continue
 		}
 
 		// Check deprecated prop.
 		if def.Deprecated {
-			violations = append(violations, Violation{
+			violation := Violation{
 				Rule:      "deprecated-prop",
 				Message:   fmt.Sprintf("Prop %q on %q is deprecated", propName, usage.ComponentName),
 				Severity:  "warning",
 				Line:      usage.Line,
 				Column:    usage.Column,
 				Component: usage.ComponentName,
-			})
+			}
+			if def.ReplacedBy != "" {
+				violation.Suggestion = fmt.Sprintf("Use %s instead", def.ReplacedBy)
+				// Renaming must not duplicate a prop that is already set.
+				if _, set := usage.Props[def.ReplacedBy]; !set {
+					violation.replacement = def.ReplacedBy
+					violation.usage = &usage
+					violation.prop = propName
+				}
+			}
+			violations = append(violations, violation)
+		}
+
+		// Check the value kind against the prop type.
+		if mismatch := checkPropType(usage, propName, def); mismatch != nil {
+			violations = append(violations, *mismatch)
+			continue
 		}
 
-		// Check allowed values (only for literal string values).
-		if propValue != "" && propValue != "true" && len(def.AllowedValues) > 0 {
+		// Check allowed values against the literal value, or every value an
+		// expression like {cond ? "a" : "b"} can produce.
+		if len(def.AllowedValues) == 0 {
+			continue
+		}
+		values := usage.PropValues[propName]
+		if len(values) == 0 && propValue != "" && propValue != "true" {
+			values = []string{propValue}
+		}
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
 			found := false
 			for _, allowed := range def.AllowedValues {
-				if propValue == allowed {
+				if value == allowed {
 					found = true
 					break
 				}
 			}
 			if !found {
-				suggestion := ""
-				if def.Default != "" {
-					suggestion = fmt.Sprintf("Use %q instead", def.Default)
+				violation := Violation{
+					Rule:      "invalid-prop-value",
+					Message:   fmt.Sprintf("Prop %q on %q has invalid value %q (allowed: %s)", propName, usage.ComponentName, value, strings.Join(def.AllowedValues, ", ")),
+					Severity:  "warning",
+					Line:      usage.Line,
+					Column:    usage.Column,
+					Component: usage.ComponentName,
+					usage:     &usage,
+					prop:      propName,
+					value:     value,
 				}
-				violations = append(violations, Violation{
-					Rule:       "invalid-prop-value",
-					Message:    fmt.Sprintf("Prop %q on %q has invalid value %q (allowed: %s)", propName, usage.ComponentName, propValue, strings.Join(def.AllowedValues, ", ")),
-					Severity:   "warning",
-					Line:       usage.Line,
-					Column:     usage.Column,
-					Component:  usage.ComponentName,
-					Suggestion: suggestion,
-				})
+				if matches := suggestMatches(value, def.AllowedValues); len(matches) > 0 {
+					violation.Suggestion = didYouMean("%q", matches)
+					if len(matches) == 1 {
+						violation.replacement = matches[0]
+					}
+				} else if def.Default != "" {
+					violation.Suggestion = fmt.Sprintf("Use %q instead", def.Default)
+				}
+				violations = append(violations, violation)
 			}
 		}
 	}
@@ -294,81 +636,354 @@ func (v *Validator) checkProps(usage JSXUsage, comp *catalog.Component) []Violat
 	return violations
 }
 
-// checkComposition validates sub-component placement against allowed_parents.
-func (v *Validator) checkComposition(usage JSXUsage) []Violation {
+// compositionRule checks where sub-components are placed.
+type compositionRule struct{}
+
+func (compositionRule) Info() []RuleInfo {
+	return []RuleInfo{
+		{ID: "composition-violation", Description: "Sub-component is used outside its allowed parents", Severity: "error"},
+	}
+}
+
+func (compositionRule) Check(page *Page) []Violation {
 	var violations []Violation
+	for _, usage := range page.Extraction.Usages {
+		violations = append(violations, page.checkComposition(usage)...)
+	}
+	return violations
+}
 
-	subDef, ok := v.index.SubComponentDef[usage.ComponentName]
-	if !ok || len(subDef.AllowedParents) == 0 {
+// checkComposition validates sub-component placement against
+// allowed_parents, allowed_ancestors, and required_ancestor. At most one
+// violation is reported per usage.
+func (p *Page) checkComposition(usage JSXUsage) []Violation {
+	subDef, ok := p.Index.SubComponentDef[usage.ComponentName]
+	if !ok {
 		return nil
 	}
 
-	if usage.ParentComponent == "" {
-		violations = append(violations, Violation{
-			Rule:       "composition-violation",
-			Message:    fmt.Sprintf("%q must be a child of %s", usage.ComponentName, strings.Join(subDef.AllowedParents, " or ")),
-			Severity:   "error",
-			Line:       usage.Line,
-			Column:     usage.Column,
-			Component:  usage.ComponentName,
-			Suggestion: fmt.Sprintf("Wrap in <%s>", subDef.AllowedParents[0]),
-		})
-		return violations
+	// The fix wraps the element in wrapper, the first allowed parent or ancestor.
+	violation := func(message, suggestion, wrapper string) []Violation {
+		return []Violation{{
+			Rule:        "composition-violation",
+			Message:     message,
+			Severity:    "error",
+			Line:        usage.Line,
+			Column:      usage.Column,
+			Component:   usage.ComponentName,
+			Suggestion:  suggestion,
+			usage:       &usage,
+			replacement: wrapper,
+		}}
 	}
 
-	allowed := false
-	for _, parent := range subDef.AllowedParents {
-		if usage.ParentComponent == parent {
-			allowed = true
-			break
+	if len(subDef.AllowedParents) > 0 {
+		if usage.ParentComponent == "" {
+			return violation(
+				fmt.Sprintf("%q must be a child of %s", usage.ComponentName, strings.Join(subDef.AllowedParents, " or ")),
+				fmt.Sprintf("Wrap in <%s>", subDef.AllowedParents[0]), subDef.AllowedParents[0])
+		}
+		if !containsName(subDef.AllowedParents, usage.ParentComponent) {
+			return violation(
+				fmt.Sprintf("%q is inside %q but must be a child of %s", usage.ComponentName, usage.ParentComponent, strings.Join(subDef.AllowedParents, " or ")),
+				fmt.Sprintf("Move inside <%s>", subDef.AllowedParents[0]), subDef.AllowedParents[0])
 		}
 	}
 
-	if !allowed {
-		violations = append(violations, Violation{
-			Rule:       "composition-violation",
-			Message:    fmt.Sprintf("%q is inside %q but must be a child of %s", usage.ComponentName, usage.ParentComponent, strings.Join(subDef.AllowedParents, " or ")),
-			Severity:   "error",
-			Line:       usage.Line,
-			Column:     usage.Column,
-			Component:  usage.ComponentName,
-			Suggestion: fmt.Sprintf("Move inside <%s>", subDef.AllowedParents[0]),
-		})
+	if len(subDef.AllowedAncestors) > 0 {
+		found := false
+		for _, ancestor := range usage.Ancestors {
+			if containsName(subDef.AllowedAncestors, ancestor) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return violation(
+				fmt.Sprintf("%q must be inside %s", usage.ComponentName, strings.Join(subDef.AllowedAncestors, " or ")),
+				fmt.Sprintf("Move inside <%s>", subDef.AllowedAncestors[0]), subDef.AllowedAncestors[0])
+		}
 	}
 
-	return violations
+	if subDef.RequiredAncestor != "" && !containsName(usage.Ancestors, subDef.RequiredAncestor) {
+		return violation(
+			fmt.Sprintf("%q must be inside <%s>", usage.ComponentName, subDef.RequiredAncestor),
+			fmt.Sprintf("Wrap in <%s>", subDef.RequiredAncestor), subDef.RequiredAncestor)
+	}
+
+	return nil
+}
+
+// mustContainRule checks that components contain their required children.
+type mustContainRule struct{}
+
+func (mustContainRule) Info() []RuleInfo {
+	return []RuleInfo{
+		{ID: "missing-child", Description: "Component is missing a required child sub-component", Severity: "error"},
+	}
 }
 
-// checkMustContain validates that parent components contain required children.
-func (v *Validator) checkMustContain(usages []JSXUsage, childrenOf map[string]map[string]bool) []Violation {
+func (mustContainRule) Check(page *Page) []Violation {
+	return page.checkMustContain(page.Extraction.Usages)
+}
+
+// checkMustContain validates that components contain their required
+// children, directly or nested in other elements.
+func (p *Page) checkMustContain(usages []JSXUsage) []Violation {
 	var violations []Violation
 
 	// For each usage that has must_contain sub-components, check children.
-	for _, usage := range usages {
-		subDef, ok := v.index.SubComponentDef[usage.ComponentName]
+	for i, usage := range usages {
+		subDef, ok := p.Index.SubComponentDef[usage.ComponentName]
 		if !ok || len(subDef.MustContain) == 0 {
 			continue
 		}
 
-		children := childrenOf[usage.ComponentName]
+		descendants := descendantNames(usages, i)
 		for _, required := range subDef.MustContain {
-			if children == nil || !children[required] {
+			if !descendants[required] {
 				violations = append(violations, Violation{
-					Rule:       "missing-child",
-					Message:    fmt.Sprintf("%q must contain a <%s> child", usage.ComponentName, required),
+					Rule:        "missing-child",
+					Message:     fmt.Sprintf("%q must contain a <%s> child", usage.ComponentName, required),
+					Severity:    "error",
+					Line:        usage.Line,
+					Column:      usage.Column,
+					Component:   usage.ComponentName,
+					Suggestion:  fmt.Sprintf("Add <%s> inside <%s>", required, usage.ComponentName),
+					usage:       &usage,
+					replacement: required,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// descendantNames returns the names of the components inside usages[i].
+// Usages are in document order, each element before its children, so these
+// are the usages that follow it and are nested deeper than it.
+func descendantNames(usages []JSXUsage, i int) map[string]bool {
+	names := make(map[string]bool)
+	depth := len(usages[i].Ancestors)
+	for _, usage := range usages[i+1:] {
+		if len(usage.Ancestors) <= depth {
+			break
+		}
+		names[usage.ComponentName] = true
+	}
+	return names
+}
+
+// childrenRule checks children against allowed_children and children policies.
+type childrenRule struct{}
+
+func (childrenRule) Info() []RuleInfo {
+	return []RuleInfo{
+		{ID: "invalid-child", Description: "Child is not allowed by the parent's allowed_children or children policy", Severity: "error"},
+	}
+}
+
+func (childrenRule) Check(page *Page) []Violation {
+	return page.checkChildren(page.Extraction.Usages)
+}
+
+// checkChildren validates direct children against the parent's
+// allowed_children and each component's children policy.
+func (p *Page) checkChildren(usages []JSXUsage) []Violation {
+	var violations []Violation
+
+	for _, usage := range usages {
+		// Children policy of the component itself.
+		if _, policy, ok := p.childRules(usage.ComponentName); ok {
+			switch {
+			case policy == catalog.ChildrenNone && usage.HasChildren:
+				violations = append(violations, Violation{
+					Rule:       "invalid-child",
+					Message:    fmt.Sprintf("%q does not accept children", usage.ComponentName),
 					Severity:   "error",
 					Line:       usage.Line,
 					Column:     usage.Column,
 					Component:  usage.ComponentName,
-					Suggestion: fmt.Sprintf("Add <%s> inside <%s>", required, usage.ComponentName),
+					Suggestion: fmt.Sprintf("Use a self-closing <%s />", usage.ComponentName),
+				})
+			case policy == catalog.ChildrenNoText && usage.HasText:
+				violations = append(violations, Violation{
+					Rule:       "invalid-child",
+					Message:    fmt.Sprintf("%q does not accept text children", usage.ComponentName),
+					Severity:   "error",
+					Line:       usage.Line,
+					Column:     usage.Column,
+					Component:  usage.ComponentName,
+					Suggestion: "Wrap the text in an element",
 				})
 			}
 		}
+
+		// Placement under the parent's allowed_children. Components outside
+		// the catalog may be wrappers, so only catalog components are checked.
+		if usage.ParentComponent == "" || !p.isCatalogComponent(usage.ComponentName) {
+			continue
+		}
+		allowed, _, ok := p.childRules(usage.ParentComponent)
+		if !ok || len(allowed) == 0 || containsName(allowed, usage.ComponentName) {
+			continue
+		}
+		// A sub-component outside its allowed_parents is already reported
+		// as a composition-violation.
+		if subDef, isSub := p.Index.SubComponentDef[usage.ComponentName]; isSub &&
+			len(subDef.AllowedParents) > 0 && !containsName(subDef.AllowedParents, usage.ParentComponent) {
+			continue
+		}
+		violations = append(violations, Violation{
+			Rule:       "invalid-child",
+			Message:    fmt.Sprintf("%q is not an allowed child of %q (allowed: %s)", usage.ComponentName, usage.ParentComponent, strings.Join(allowed, ", ")),
+			Severity:   "error",
+			Line:       usage.Line,
+			Column:     usage.Column,
+			Component:  usage.ComponentName,
+			Suggestion: fmt.Sprintf("Move <%s> out of <%s>", usage.ComponentName, usage.ParentComponent),
+		})
 	}
 
 	return violations
 }
 
+// childRules returns the allowed_children and children policy of a catalog
+// component or sub-component. ok is false for names not in the catalog.
+func (p *Page) childRules(name string) (allowed []string, policy string, ok bool) {
+	if comp, found := p.Index.ComponentByName[name]; found {
+		return comp.AllowedChildren, comp.Children, true
+	}
+	if sub, found := p.Index.SubComponentDef[name]; found {
+		return sub.AllowedChildren, sub.Children, true
+	}
+	return nil, "", false
+}
+
+// componentNames returns every catalog component and sub-component name,
+// plus dotted sub-component aliases, as "did you mean" candidates.
+func (p *Page) componentNames() []string {
+	names := make([]string, 0, len(p.Index.ComponentByName)+len(p.Index.SubComponentDef)+len(p.Index.SubComponentByAlias))
+	for name := range p.Index.ComponentByName {
+		names = append(names, name)
+	}
+	for name := range p.Index.SubComponentDef {
+		names = append(names, name)
+	}
+	for alias := range p.Index.SubComponentByAlias {
+		names = append(names, alias)
+	}
+	return names
+}
+
+// isCatalogComponent reports whether name is a catalog component or sub-component.
+func (p *Page) isCatalogComponent(name string) bool {
+	_, isTopLevel := p.Index.ComponentByName[name]
+	_, isSubComponent := p.Index.SubComponentByName[name]
+	return isTopLevel || isSubComponent
+}
+
+// containsName reports whether names contains name.
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// intrinsicsRule flags raw HTML elements that a catalog component replaces.
+type intrinsicsRule struct{}
+
+func (intrinsicsRule) Info() []RuleInfo {
+	return []RuleInfo{
+		{ID: "use-catalog-components", Description: "Raw HTML element is used where a catalog component replaces it", Severity: "warning"},
+	}
+}
+
+func (intrinsicsRule) Check(page *Page) []Violation {
+	return page.checkIntrinsics(page.Extraction.Intrinsics)
+}
+
+// checkIntrinsics flags raw HTML elements that a catalog component replaces.
+func (p *Page) checkIntrinsics(intrinsics []JSXUsage) []Violation {
+	var violations []Violation
+
+	for i := range intrinsics {
+		usage := &intrinsics[i]
+		comp, ok := p.Index.ComponentByHTML[usage.ComponentName]
+		if !ok {
+			continue
+		}
+		violations = append(violations, Violation{
+			Rule:       "use-catalog-components",
+			Message:    fmt.Sprintf("Raw <%s> element should use the catalog component <%s>", usage.ComponentName, comp.Name),
+			Severity:   "warning",
+			Line:       usage.Line,
+			Column:     usage.Column,
+			Component:  comp.Name,
+			Suggestion: fmt.Sprintf("Replace <%s> with <%s> from %q", usage.ComponentName, comp.Name, comp.ImportPath),
+			usage:      usage,
+		})
+	}
+
+	return violations
+}
+
+// Retain keeps only the violations for which keep returns true, and updates
+// Valid and Summary to match. Fixes are left as they are, except that their
+// links follow the retained violations, or are -1 for a dropped one.
+func (r *ValidationResult) Retain(keep func(Violation) bool) {
+	violations := r.Violations[:0]
+	kept := make([]int, len(r.Violations)) // old index → new index, or -1
+	for i, v := range r.Violations {
+		kept[i] = -1
+		if keep(v) {
+			kept[i] = len(violations)
+			violations = append(violations, v)
+		}
+	}
+	for i := range r.Fixes {
+		if old := r.Fixes[i].Violation; old >= 0 && old < len(kept) {
+			r.Fixes[i].Violation = kept[old]
+		}
+	}
+	r.Violations = violations
+	r.Valid = len(filterBySeverity(violations, "error")) == 0
+	r.Summary = buildSummary(violations)
+}
+
+// sortViolations orders violations by line and column, keeping the order of
+// violations at the same position, and updates the links of fixes to match.
+func sortViolations(violations []Violation, fixes []AutoFix) {
+	order := make([]int, len(violations)) // new index → old index
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		va, vb := violations[order[a]], violations[order[b]]
+		if va.Line != vb.Line {
+			return va.Line < vb.Line
+		}
+		return va.Column < vb.Column
+	})
+
+	sorted := make([]Violation, len(violations))
+	moved := make([]int, len(violations)) // old index → new index
+	for to, from := range order {
+		sorted[to] = violations[from]
+		moved[from] = to
+	}
+	copy(violations, sorted)
+	for i := range fixes {
+		if from := fixes[i].Violation; from >= 0 && from < len(moved) {
+			fixes[i].Violation = moved[from]
+		}
+	}
+}
+
 // filterBySeverity returns violations matching the given severity.
 func filterBySeverity(violations []Violation, severity string) []Violation {
 	var result []Violation
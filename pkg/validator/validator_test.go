@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/gnana997/uispec/pkg/catalog"
@@ -24,10 +26,12 @@ func testValidator() *Validator {
 				Category:      "actions",
 				ImportPath:    "@/components/ui/button",
 				ImportedNames: []string{"Button"},
+				ReplacesHTML:  []string{"button"},
 				Props: []catalog.Prop{
 					{Name: "variant", Type: "string", AllowedValues: []string{"default", "destructive", "outline"}, Default: "default"},
 					{Name: "size", Type: "string", AllowedValues: []string{"default", "sm", "lg"}},
 					{Name: "asChild", Type: "boolean"},
+					{Name: "onClick", Type: "function"},
 				},
 			},
 			{
@@ -37,9 +41,12 @@ func testValidator() *Validator {
 				ImportPath:    "@/components/ui/dialog",
 				ImportedNames: []string{"Dialog", "DialogTrigger", "DialogContent", "DialogTitle"},
 				SubComponents: []catalog.SubComponent{
-					{Name: "DialogTrigger", Description: "Opens the dialog", AllowedParents: []string{"Dialog"}},
-					{Name: "DialogContent", Description: "Content container", AllowedParents: []string{"Dialog"}, MustContain: []string{"DialogTitle"}},
-					{Name: "DialogTitle", Description: "Title", AllowedParents: []string{"DialogContent"}},
+					{Name: "DialogTrigger", Alias: "Dialog.Trigger", Description: "Opens the dialog", AllowedParents: []string{"Dialog"}},
+					{Name: "DialogContent", Alias: "Dialog.Content", Description: "Content container", AllowedParents: []string{"Dialog"}, MustContain: []string{"DialogTitle"}, Props: []catalog.Prop{
+						{Name: "side", Type: "string", AllowedValues: []string{"top", "right", "bottom", "left"}, Default: "right"},
+						{Name: "onEscapeKeyDown", Type: "function"},
+					}},
+					{Name: "DialogTitle", Alias: "Dialog.Title", Description: "Title", AllowedParents: []string{"DialogContent"}},
 				},
 			},
 		},
@@ -49,6 +56,12 @@ func testValidator() *Validator {
 	return NewValidator(cat, idx, pm)
 }
 
+// testPage wraps the validator's catalog in an empty Page so checks can be
+// called directly.
+func testPage(v *Validator) *Page {
+	return &Page{Catalog: v.catalog, Index: v.index, Extraction: &JSXExtraction{}}
+}
+
 func TestValidatePage_ValidCode(t *testing.T) {
 	v := testValidator()
 	defer v.parser.Close()
@@ -163,6 +176,89 @@ export default function Page() {
 	assert.True(t, found, "expected composition-violation for DialogContent outside Dialog")
 }
 
+func TestValidatePage_TransparentWrapper(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+	v.SetTransparentComponents([]string{"Stack"})
+
+	code := `
+import { Fragment } from "react"
+import { Dialog, DialogContent, DialogTitle } from "@/components/ui/dialog"
+
+export default function Page() {
+  return (
+    <Dialog>
+      <Fragment>
+        <DialogContent>
+          <Stack>
+            <DialogTitle>Hello</DialogTitle>
+          </Stack>
+        </DialogContent>
+      </Fragment>
+    </Dialog>
+  )
+}
+`
+	result := v.ValidatePage(code, false)
+
+	for _, viol := range result.Violations {
+		assert.NotContains(t, []string{"composition-violation", "missing-child"}, viol.Rule, viol.Message)
+	}
+}
+
+func TestSkipTransparentAncestors(t *testing.T) {
+	v := testValidator()
+	v.SetTransparentComponents([]string{"Stack"})
+	extraction := &JSXExtraction{Usages: []JSXUsage{
+		{ComponentName: "DialogTitle", ParentComponent: "Stack", Ancestors: []string{"Dialog", "DialogContent", "React.Fragment", "Stack"}},
+		{ComponentName: "Dialog", ParentComponent: "Fragment", Ancestors: []string{"Fragment"}},
+	}}
+
+	v.skipTransparentAncestors(extraction)
+
+	assert.Equal(t, "DialogContent", extraction.Usages[0].ParentComponent)
+	assert.Equal(t, []string{"Dialog", "DialogContent"}, extraction.Usages[0].Ancestors)
+	assert.Equal(t, "", extraction.Usages[1].ParentComponent)
+	assert.Empty(t, extraction.Usages[1].Ancestors)
+}
+
+func TestCheckComposition_Ancestors(t *testing.T) {
+	v := testValidator()
+	title := v.index.SubComponentDef["DialogTitle"]
+	title.AllowedParents = nil
+	title.AllowedAncestors = []string{"DialogContent", "Sheet"}
+	v.index.SubComponentDef["DialogTrigger"].RequiredAncestor = "Dialog"
+
+	tests := []struct {
+		name    string
+		usage   JSXUsage
+		message string
+	}{
+		{"nested under allowed ancestor", JSXUsage{ComponentName: "DialogTitle", ParentComponent: "Card", Ancestors: []string{"Dialog", "DialogContent", "Card"}}, ""},
+		{"no allowed ancestor", JSXUsage{ComponentName: "DialogTitle", ParentComponent: "Card", Ancestors: []string{"Dialog", "Card"}}, `"DialogTitle" must be inside DialogContent or Sheet`},
+		{"required ancestor present", JSXUsage{ComponentName: "DialogTrigger", ParentComponent: "Dialog", Ancestors: []string{"Dialog"}}, ""},
+		{"allowed parent checked first", JSXUsage{ComponentName: "DialogTrigger"}, `"DialogTrigger" must be a child of Dialog`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := testPage(v).checkComposition(tt.usage)
+			if tt.message == "" {
+				assert.Empty(t, violations)
+				return
+			}
+			require.Len(t, violations, 1)
+			assert.Equal(t, "composition-violation", violations[0].Rule)
+			assert.Equal(t, tt.message, violations[0].Message)
+		})
+	}
+
+	v.index.SubComponentDef["DialogTrigger"].AllowedParents = nil
+	violations := testPage(v).checkComposition(JSXUsage{ComponentName: "DialogTrigger", ParentComponent: "Card", Ancestors: []string{"Card"}})
+	require.Len(t, violations, 1)
+	assert.Equal(t, `"DialogTrigger" must be inside <Dialog>`, violations[0].Message)
+	assert.Equal(t, "Wrap in <Dialog>", violations[0].Suggestion)
+}
+
 func TestValidatePage_MissingChild(t *testing.T) {
 	v := testValidator()
 	defer v.parser.Close()
@@ -194,6 +290,148 @@ export default function Page() {
 	assert.True(t, found, "expected missing-child violation for DialogContent missing DialogTitle")
 }
 
+func TestValidatePage_MustContain(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	tests := []struct {
+		name  string
+		body  string
+		lines []int // lines of the expected missing-child violations
+	}{
+		{
+			name: "direct child",
+			body: `
+    <Dialog>
+      <DialogContent>
+        <DialogTitle>Title</DialogTitle>
+      </DialogContent>
+    </Dialog>`,
+		},
+		{
+			name: "nested child",
+			body: `
+    <Dialog>
+      <DialogContent>
+        <Header>
+          <DialogTitle>Title</DialogTitle>
+        </Header>
+      </DialogContent>
+    </Dialog>`,
+		},
+		{
+			name: "each instance needs its own child",
+			body: `
+    <Dialog>
+      <DialogContent>
+        <DialogTitle>Title</DialogTitle>
+      </DialogContent>
+      <DialogContent>
+        <p>No title here</p>
+      </DialogContent>
+    </Dialog>`,
+			lines: []int{14},
+		},
+		{
+			name: "sibling does not count",
+			body: `
+    <Dialog>
+      <DialogContent>
+        <p>No title here</p>
+      </DialogContent>
+      <DialogTitle>Title</DialogTitle>
+    </Dialog>`,
+			lines: []int{11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := `
+import { Dialog, DialogContent, DialogTitle } from "@/components/ui/dialog"
+
+function Header({ children }) {
+  return <div>{children}</div>
+}
+
+export default function Page() {
+  return (` + tt.body + `
+  )
+}
+`
+			result := v.ValidatePage(code, false)
+
+			var lines []int
+			for _, viol := range result.Violations {
+				if viol.Rule == "missing-child" {
+					lines = append(lines, viol.Line)
+				}
+			}
+			assert.Equal(t, tt.lines, lines)
+		})
+	}
+}
+
+func TestValidatePage_InvalidChild(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+	v.index.ComponentByName["Button"].Children = catalog.ChildrenNone
+	v.index.ComponentByName["Dialog"].AllowedChildren = []string{"DialogTrigger", "DialogContent"}
+
+	code := `
+import { Dialog, DialogTrigger } from "@/components/ui/dialog"
+import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  return (
+    <Dialog>
+      <DialogTrigger />
+      <Button>Open</Button>
+    </Dialog>
+  )
+}
+`
+	result := v.ValidatePage(code, false)
+
+	var messages []string
+	for _, viol := range result.Violations {
+		if viol.Rule == "invalid-child" {
+			messages = append(messages, viol.Message)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		`"Button" does not accept children`,
+		`"Button" is not an allowed child of "Dialog" (allowed: DialogTrigger, DialogContent)`,
+	}, messages)
+}
+
+func TestCheckChildren(t *testing.T) {
+	v := testValidator()
+	v.index.ComponentByName["Button"].Children = catalog.ChildrenNoText
+	v.index.ComponentByName["Dialog"].AllowedChildren = []string{"DialogTrigger", "DialogContent"}
+	v.index.SubComponentDef["DialogTitle"].Children = catalog.ChildrenNone
+
+	usages := []JSXUsage{
+		{ComponentName: "Dialog", HasChildren: true, Line: 1},
+		{ComponentName: "Button", ParentComponent: "Dialog", HasChildren: true, HasText: true, Line: 2},
+		{ComponentName: "Chart", ParentComponent: "Dialog", Line: 3},                          // not in the catalog
+		{ComponentName: "DialogTitle", ParentComponent: "Dialog", HasChildren: true, Line: 4}, // placement is a composition-violation
+		{ComponentName: "DialogTrigger", ParentComponent: "Dialog", HasText: true, Line: 5},
+	}
+
+	violations := testPage(v).checkChildren(usages)
+
+	require.Len(t, violations, 3)
+	assert.Equal(t, `"Button" does not accept text children`, violations[0].Message)
+	assert.Equal(t, 2, violations[1].Line)
+	assert.Equal(t, "Move <Button> out of <Dialog>", violations[1].Suggestion)
+	assert.Equal(t, `"DialogTitle" does not accept children`, violations[2].Message)
+	for _, viol := range violations {
+		assert.Equal(t, "invalid-child", viol.Rule)
+		assert.Equal(t, "error", viol.Severity)
+	}
+}
+
 func TestValidatePage_ValidCompoundComponent(t *testing.T) {
 	v := testValidator()
 	defer v.parser.Close()
@@ -234,6 +472,119 @@ func TestValidatePage_AutoFixMissingImport(t *testing.T) {
 	assert.Contains(t, result.FixedCode, `import { Button } from "@/components/ui/button"`)
 }
 
+func TestValidatePage_AutoFixSplitsImport(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `import { Button, Dialog } from '@/components/ui/button';
+
+export default function Page() {
+  return <Dialog><Button>Open</Button></Dialog>
+}
+`
+	result := v.ValidatePage(code, true)
+
+	assert.Equal(t, `import { Button } from '@/components/ui/button';
+import { Dialog } from '@/components/ui/dialog';
+
+export default function Page() {
+  return <Dialog><Button>Open</Button></Dialog>
+}
+`, result.FixedCode)
+}
+
+func TestValidatePage_JSXFile(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `import { Button } from "@/components/ui/button"
+
+export default function Page({ onSave }) {
+  return <Button variant="fancy" onClick={() => onSave()}>Save</Button>
+}
+`
+	result := v.ValidatePageWithOptions(code, ValidateOptions{Filename: "src/Page.jsx"})
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "invalid-prop-value", result.Violations[0].Rule)
+}
+
+func TestValidatePage_FixFilter(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  return (
+    <div>
+      <Button
+        variant="destructiv"
+      >
+        A
+      </Button>
+      <Button variant="outlin">B</Button>
+    </div>
+  )
+}
+`
+	result := v.ValidatePageWithOptions(code, ValidateOptions{
+		AutoFix: true,
+		Fix:     func(viol Violation) bool { return viol.Line == 11 },
+	})
+
+	require.Len(t, result.Violations, 2, "unfixed violations are still reported")
+	assert.Equal(t, 6, result.Violations[0].Line)
+	assert.Equal(t, 10, result.Violations[0].EndLine)
+	assert.Equal(t, 0, result.Violations[1].EndLine, "single-line elements have no end line")
+
+	require.Len(t, result.Fixes, 1)
+	assert.Contains(t, result.FixedCode, `variant="destructiv"`)
+	assert.Contains(t, result.FixedCode, `<Button variant="outline">B</Button>`)
+}
+
+func TestValidatePage_UnsupportedLanguage(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	result := v.ValidatePageWithOptions(`<Button />`, ValidateOptions{Language: "vue"})
+
+	assert.False(t, result.Valid)
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "parse-error", result.Violations[0].Rule)
+	assert.Contains(t, result.Violations[0].Message, `unsupported language "vue"`)
+}
+
+func TestValidatePage_AutoFixDiff(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  return <Button variant="fancy">Click</Button>
+}
+`
+	result := v.ValidatePageWithOptions(code, ValidateOptions{
+		AutoFix:   true,
+		Filename:  "app/page.tsx",
+		FixFormat: FixFormatDiff,
+	})
+
+	require.NotEmpty(t, result.Fixes)
+	assert.Empty(t, result.FixedCode)
+	assert.Equal(t, `--- a/app/page.tsx
++++ b/app/page.tsx
+@@ -1,5 +1,5 @@
+ import { Button } from "@/components/ui/button"
+ 
+ export default function Page() {
+-  return <Button variant="fancy">Click</Button>
++  return <Button variant="default">Click</Button>
+ }
+`, result.FixDiff)
+}
+
 func TestValidatePage_AutoFixInvalidPropValue(t *testing.T) {
 	v := testValidator()
 	defer v.parser.Close()
@@ -258,6 +609,639 @@ export default function Page() {
 	assert.Contains(t, result.FixedCode, `variant="default"`)
 }
 
+func TestValidatePage_AutoFixInvalidPropValueSpan(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	// The fix uses the literal's position, not the quoted value in the
+	// message, so values with quotes and ternary branches are found.
+	code := `
+import { Button } from "@/components/ui/button"
+
+export default function Page({ wide }) {
+  return (
+    <>
+      <Button variant='big "bold"'>A</Button>
+      <Button variant={wide ? 'fancy' : "outline"}>B</Button>
+    </>
+  )
+}
+`
+	result := v.ValidatePage(code, true)
+
+	require.Len(t, result.Fixes, 2)
+	assert.Contains(t, result.FixedCode, `<Button variant='default'>A</Button>`)
+	assert.Contains(t, result.FixedCode, `<Button variant={wide ? 'default' : "outline"}>B</Button>`)
+}
+
+func TestValidatePage_FixesLinkToViolations(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+export default function Page() {
+  return (
+    <>
+      <Button variant="fancy">A</Button>
+      <Button variant="plain">B</Button>
+    </>
+  )
+}
+`
+	result := v.ValidatePage(code, true)
+
+	require.NotEmpty(t, result.Fixes)
+	linked := make(map[int]bool)
+	for _, fix := range result.Fixes {
+		require.GreaterOrEqual(t, fix.Violation, 0)
+		require.Less(t, fix.Violation, len(result.Violations))
+		viol := result.Violations[fix.Violation]
+		assert.Equal(t, fix.Rule, viol.Rule)
+		if fix.Rule == "invalid-prop-value" {
+			assert.Equal(t, viol.Line, fix.Line)
+		}
+		linked[fix.Violation] = true
+	}
+	// Both invalid values and the missing import are fixed, each linked to its own violation.
+	assert.Len(t, linked, 3)
+}
+
+func TestValidatePage_ViolationsInSourceOrder(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  return (
+    <>
+      <button>Raw</button>
+      <Button variant="fancy">A</Button>
+      <Button size="huge">B</Button>
+    </>
+  )
+}
+`
+	result := v.ValidatePage(code, false)
+
+	var got []string
+	for _, viol := range result.Violations {
+		got = append(got, fmt.Sprintf("%d:%s", viol.Line, viol.Rule))
+	}
+	assert.Equal(t, []string{"7:use-catalog-components", "8:invalid-prop-value", "9:invalid-prop-value"}, got)
+}
+
+func TestValidatePage_PropTypeMismatch(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  return (
+    <>
+      <Button asChild="yes">A</Button>
+      <Button asChild={false} size={"sm"}>B</Button>
+    </>
+  )
+}
+`
+	result := v.ValidatePage(code, false)
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "prop-type-mismatch", result.Violations[0].Rule)
+	assert.Equal(t, 7, result.Violations[0].Line)
+}
+
+func TestValidatePage_ExpressionPropValues(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import { Button } from "@/components/ui/button"
+
+const SIZE = "xl"
+
+export default function Page({ isDanger }) {
+  return (
+    <>
+      <Button variant={isDanger ? "destructive" : "primray"} size={SIZE}>A</Button>
+      <Button variant={"outline"} size={` + "`sm`" + `}>B</Button>
+    </>
+  )
+}
+`
+	result := v.ValidatePage(code, true)
+
+	require.Len(t, result.Violations, 2)
+	messages := result.Violations[0].Message + "\n" + result.Violations[1].Message
+	assert.Contains(t, messages, `invalid value "primray"`)
+	assert.Contains(t, messages, `invalid value "xl"`)
+
+	// Only the inline branch can be fixed; SIZE is declared elsewhere.
+	require.Len(t, result.Fixes, 1)
+	assert.Contains(t, result.FixedCode, `isDanger ? "destructive" : "default"`)
+}
+
+func TestValidatePage_SubComponentProps(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import { Dialog, DialogContent, DialogTitle, DialogTrigger } from "@/components/ui/dialog"
+
+export default function Page() {
+  return (
+    <Dialog>
+      <DialogTrigger asChild>Open</DialogTrigger>
+      <DialogContent side="wrong" onEscapeKeyDown={close}>
+        <DialogTitle>Title</DialogTitle>
+      </DialogContent>
+    </Dialog>
+  )
+}
+`
+	result := v.ValidatePage(code, true)
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "invalid-prop-value", result.Violations[0].Rule)
+	assert.Equal(t, "DialogContent", result.Violations[0].Component)
+	assert.Contains(t, result.FixedCode, `<DialogContent side="right"`)
+}
+
+func TestCheckProps_SubComponentRequired(t *testing.T) {
+	usage := JSXUsage{ComponentName: "SelectItem", Props: map[string]string{"position": "top"}}
+	props := []catalog.Prop{{Name: "value", Type: "string", Required: true}}
+
+	violations := checkProps(usage, props)
+
+	rules := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		rules = append(rules, violation.Rule)
+	}
+	assert.ElementsMatch(t, []string{"missing-required-prop", "unknown-prop"}, rules)
+}
+
+func TestGenerateFixes_SubComponentPropValue(t *testing.T) {
+	v := testValidator()
+	code := `<DialogContent side="wrong">`
+	violations := []Violation{{
+		Rule:      "invalid-prop-value",
+		Message:   `Prop "side" on "DialogContent" has invalid value "wrong" (allowed: top, right, bottom, left)`,
+		Line:      1,
+		Column:    1,
+		Component: "DialogContent",
+		usage: &JSXUsage{
+			ComponentName: "DialogContent",
+			attrs:         map[string]attrSpan{"side": {name: spanOf(code, "side"), value: spanOf(code, `"wrong"`), literals: []span{spanOf(code, "wrong")}}},
+		},
+		prop:  "side",
+		value: "wrong",
+	}}
+
+	fixes, fixed := GenerateFixes(code, violations, v.index)
+
+	require.Len(t, fixes, 1)
+	assert.Equal(t, `<DialogContent side="right">`, fixed)
+}
+
+func TestValidatePage_AliasedImport(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import { Button as Btn } from "@/components/ui/button"
+
+export default function Page() {
+  return <Btn variant="fancy">Click</Btn>
+}
+`
+	result := v.ValidatePage(code, false)
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "invalid-prop-value", result.Violations[0].Rule)
+	assert.Equal(t, "Button", result.Violations[0].Component)
+}
+
+func TestValidatePage_NamespaceImport(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import * as UI from "@/components/dialog"
+
+export default function Page() {
+  return (
+    <UI.Dialog>
+      <UI.DialogContent>
+        <UI.DialogTitle>Hi</UI.DialogTitle>
+      </UI.DialogContent>
+    </UI.Dialog>
+  )
+}
+`
+	result := v.ValidatePage(code, true)
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "wrong-import-path", result.Violations[0].Rule)
+	assert.Equal(t, "Dialog", result.Violations[0].Component)
+	assert.Contains(t, result.FixedCode, `import * as UI from "@/components/ui/dialog"`)
+}
+
+func TestValidatePage_DefaultImport(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import Btn from "@/components/ui/button"
+import Modal from "@/components/ui/dialog"
+
+export default function Page() {
+  return (
+    <Modal>
+      <Modal.Content>
+        <Modal.Title>Hi</Modal.Title>
+        <Btn variant="fancy">Click</Btn>
+      </Modal.Content>
+    </Modal>
+  )
+}
+`
+	result := v.ValidatePage(code, false)
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "invalid-prop-value", result.Violations[0].Rule)
+	assert.Equal(t, "Button", result.Violations[0].Component)
+
+	// A path shared by two components doesn't say which one is the default.
+	v.index.ComponentByName["Button"].ImportPath = "@/components/ui/dialog"
+	result = v.ValidatePage(`
+import Thing from "@/components/ui/dialog"
+
+export default () => <Thing />
+`, false)
+
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, "unknown-component", result.Violations[0].Rule)
+	assert.Equal(t, "Thing", result.Violations[0].Component)
+}
+
+func TestResolveSubComponentAliases(t *testing.T) {
+	v := testValidator()
+	extraction := &JSXExtraction{
+		Usages: []JSXUsage{
+			{ComponentName: "Dialog"},
+			{ComponentName: "Dialog.Content", ParentComponent: "Dialog"},
+			{ComponentName: "Dialog.Title", LocalName: "UI.Dialog.Title", ParentComponent: "Dialog.Content"},
+		},
+		Intrinsics: []JSXUsage{{ComponentName: "button", ParentComponent: "Dialog.Trigger"}},
+	}
+
+	v.resolveSubComponentAliases(extraction)
+
+	assert.Equal(t, "Dialog", extraction.Usages[0].ComponentName)
+	assert.Empty(t, extraction.Usages[0].LocalName)
+	assert.Equal(t, "DialogContent", extraction.Usages[1].ComponentName)
+	assert.Equal(t, "Dialog.Content", extraction.Usages[1].LocalName)
+	assert.Equal(t, "DialogTitle", extraction.Usages[2].ComponentName)
+	assert.Equal(t, "UI.Dialog.Title", extraction.Usages[2].LocalName)
+	assert.Equal(t, "DialogContent", extraction.Usages[2].ParentComponent)
+	assert.Equal(t, "DialogTrigger", extraction.Intrinsics[0].ParentComponent)
+}
+
+func TestValidatePage_DottedSubComponents(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+import { Dialog } from "@/components/ui/dialog"
+
+export default function Page() {
+  return (
+    <Dialog>
+      <Dialog.Trigger>Open</Dialog.Trigger>
+      <Dialog.Content>
+        <p>No title</p>
+      </Dialog.Content>
+      <Dialog.Title>Misplaced</Dialog.Title>
+    </Dialog>
+  )
+}
+`
+	result := v.ValidatePage(code, false)
+
+	rules := make([]string, 0, len(result.Violations))
+	for _, violation := range result.Violations {
+		rules = append(rules, violation.Rule)
+	}
+	assert.ElementsMatch(t, []string{"composition-violation", "missing-child"}, rules)
+	for _, violation := range result.Violations {
+		assert.NotEqual(t, "unknown-component", violation.Rule)
+	}
+}
+
+func TestValidatePage_RawHTMLElement(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `
+export default function Page() {
+  return (
+    <div>
+      <button type="submit">Save</button>
+      <span>text</span>
+    </div>
+  )
+}
+`
+	result := v.ValidatePage(code, false)
+
+	require.Len(t, result.Violations, 1)
+	violation := result.Violations[0]
+	assert.Equal(t, "use-catalog-components", violation.Rule)
+	assert.Equal(t, "warning", violation.Severity)
+	assert.Equal(t, "Button", violation.Component)
+	assert.Equal(t, 5, violation.Line)
+	assert.True(t, result.Valid)
+}
+
+func TestValidatePage_AutoFixRawHTMLElement(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `export default function Page() {
+  return (
+    <button type="submit">
+      Save
+    </button>
+  )
+}
+`
+	result := v.ValidatePage(code, true)
+
+	require.Len(t, result.Fixes, 3)
+	assert.Contains(t, result.FixedCode, `<Button type="submit">`)
+	assert.Contains(t, result.FixedCode, `</Button>`)
+	assert.NotContains(t, result.FixedCode, `button>`)
+	assert.Contains(t, result.FixedCode, `import { Button } from "@/components/ui/button"`)
+}
+
+func TestGenerateFixes_RawHTMLElementAlreadyImported(t *testing.T) {
+	v := testValidator()
+	code := "import { Button } from \"@/components/ui/button\"\n<div><button>Go</button></div>"
+	violations := []Violation{{
+		Rule:      "use-catalog-components",
+		Line:      2,
+		Column:    6,
+		Component: "Button",
+		usage: &JSXUsage{
+			ComponentName: "button", Line: 2, Column: 6, CloseLine: 2,
+			tagSpan: spanAt(code, "button", 2), closeTagSpan: spanAt(code, "button", 3),
+		},
+	}}
+
+	fixes, fixed := GenerateFixes(code, violations, v.index)
+
+	require.Len(t, fixes, 2)
+	assert.Equal(t, "import { Button } from \"@/components/ui/button\"\n<div><Button>Go</Button></div>", fixed)
+}
+
+func TestValidatePage_DidYouMean(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `import { Button } from "@/components/ui/button"
+
+export default function Page() {
+  return (
+    <div>
+      <Buton>Save</Buton>
+      <Button
+        varient="outline"
+        size="LG"
+      >
+        Go
+      </Button>
+    </div>
+  )
+}
+`
+	result := v.ValidatePageWithOptions(code, ValidateOptions{AutoFix: true})
+
+	suggestions := make(map[string]string)
+	for _, viol := range result.Violations {
+		suggestions[viol.Rule] = viol.Suggestion
+	}
+	assert.Equal(t, "Did you mean <Button>?", suggestions["unknown-component"])
+	assert.Equal(t, `Did you mean "variant"?`, suggestions["unknown-prop"])
+	assert.Equal(t, `Did you mean "lg"?`, suggestions["invalid-prop-value"])
+
+	assert.Contains(t, result.FixedCode, "<Button>Save</Button>")
+	assert.Contains(t, result.FixedCode, `        variant="outline"`)
+	assert.Contains(t, result.FixedCode, `        size="lg"`)
+}
+
+func TestCheckProps_DidYouMean(t *testing.T) {
+	v := testValidator()
+	usage := JSXUsage{
+		ComponentName: "Button",
+		Props:         map[string]string{"varient": "outline", "sise": "lg", "size": "defualt"},
+	}
+
+	violations := checkProps(usage, v.index.ComponentByName["Button"].Props)
+
+	byMessage := make(map[string]Violation)
+	for _, viol := range violations {
+		byMessage[viol.Message] = viol
+	}
+	varient := byMessage[`Prop "varient" is not defined for component "Button"`]
+	assert.Equal(t, `Did you mean "variant"?`, varient.Suggestion)
+	assert.Equal(t, "variant", varient.replacement)
+
+	// "size" is already set, so renaming "sise" would duplicate it.
+	sise := byMessage[`Prop "sise" is not defined for component "Button"`]
+	assert.Equal(t, `Did you mean "size"?`, sise.Suggestion)
+	assert.Empty(t, sise.replacement)
+
+	value := byMessage[`Prop "size" on "Button" has invalid value "defualt" (allowed: default, sm, lg)`]
+	assert.Equal(t, `Did you mean "default"?`, value.Suggestion)
+	assert.Equal(t, "default", value.replacement)
+}
+
+func TestGenerateFixes_DidYouMean(t *testing.T) {
+	v := testValidator()
+	code := "<div>\n  <Buton varient=\"outline\" size=\"SM\">Go</Buton>\n</div>"
+	usage := &JSXUsage{
+		ComponentName: "Buton", Line: 2, Column: 3, CloseLine: 2,
+		tagSpan: spanAt(code, "Buton", 1), closeTagSpan: spanAt(code, "Buton", 2),
+		attrs: map[string]attrSpan{
+			"varient": {name: spanOf(code, "varient"), value: spanOf(code, `"outline"`)},
+			"size":    {name: spanOf(code, "size"), value: spanOf(code, `"SM"`), literals: []span{spanOf(code, "SM")}},
+		},
+	}
+	violations := []Violation{
+		{Rule: "unknown-component", Line: 2, Column: 3, Component: "Buton", usage: usage, replacement: "Button"},
+		{Rule: "unknown-prop", Message: `Prop "varient" is not defined for component "Button"`, Line: 2, Column: 3, Component: "Button", usage: usage, prop: "varient", replacement: "variant"},
+		{Rule: "invalid-prop-value", Message: `Prop "size" on "Button" has invalid value "SM" (allowed: default, sm, lg)`, Line: 2, Column: 3, Component: "Button", usage: usage, prop: "size", value: "SM", replacement: "sm"},
+	}
+
+	fixes, fixed := GenerateFixes(code, violations, v.index)
+
+	require.Len(t, fixes, 5)
+	assert.Equal(t, "import { Button } from \"@/components/ui/button\"\n<div>\n  <Button variant=\"outline\" size=\"sm\">Go</Button>\n</div>", fixed)
+}
+
+func TestGenerateFixes_UnknownPropSameLine(t *testing.T) {
+	v := testValidator()
+	// The misspelling also appears in another value; only the attribute name changes.
+	code := `<Button title="sise" sise="lg">Go</Button>`
+	violations := []Violation{{
+		Rule:      "unknown-prop",
+		Message:   `Prop "sise" is not defined for component "Button"`,
+		Line:      1,
+		Column:    1,
+		Component: "Button",
+		usage: &JSXUsage{
+			ComponentName: "Button",
+			attrs:         map[string]attrSpan{"sise": {name: spanAt(code, "sise", 2), value: spanOf(code, `"lg"`)}},
+		},
+		prop:        "sise",
+		replacement: "size",
+	}}
+
+	fixes, fixed := GenerateFixes(code, violations, v.index)
+	require.Len(t, fixes, 1)
+	assert.Equal(t, `<Button title="sise" size="lg">Go</Button>`, fixed)
+	assert.Equal(t, 1, fixes[0].Line)
+	assert.Equal(t, 22, fixes[0].Column)
+	assert.Equal(t, 26, fixes[0].EndColumn)
+}
+
+func TestCheckProps_DeprecatedReplacedBy(t *testing.T) {
+	v := testValidator()
+	props := append(v.index.ComponentByName["Button"].Props, catalog.Prop{Name: "kind", Type: "string", Deprecated: true, ReplacedBy: "variant"})
+
+	violations := checkProps(JSXUsage{ComponentName: "Button", Props: map[string]string{"kind": "outline"}}, props)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "Use variant instead", violations[0].Suggestion)
+	assert.Equal(t, "variant", violations[0].replacement)
+
+	// Renaming must not duplicate a prop that is already set.
+	violations = checkProps(JSXUsage{ComponentName: "Button", Props: map[string]string{"kind": "outline", "variant": "ghost"}}, props)
+	for _, viol := range violations {
+		assert.Empty(t, viol.replacement, viol.Message)
+	}
+}
+
+func TestValidatePage_AutoFixComposition(t *testing.T) {
+	v := testValidator()
+	defer v.parser.Close()
+
+	code := `import { DialogTrigger } from "@/components/ui/dialog"
+
+export default function Page() {
+  return <DialogTrigger>Open</DialogTrigger>
+}
+`
+	result := v.ValidatePage(code, true)
+
+	assert.Equal(t, `import { DialogTrigger, Dialog } from "@/components/ui/dialog"
+
+export default function Page() {
+  return <Dialog><DialogTrigger>Open</DialogTrigger></Dialog>
+}
+`, result.FixedCode)
+}
+
+func TestGenerateFixes_MissingRequiredProp(t *testing.T) {
+	v := testValidator()
+	button := v.index.ComponentByName["Button"]
+	button.Props = append(button.Props,
+		catalog.Prop{Name: "type", Type: "string", Required: true, Default: "button"},
+		catalog.Prop{Name: "tabIndex", Type: "number", Required: true, Default: "0"},
+		catalog.Prop{Name: "label", Type: "string", Required: true},
+	)
+	code := `<Button>Go</Button>`
+	usage := &JSXUsage{ComponentName: "Button", tagSpan: spanOf(code, "Button")}
+	violations := []Violation{
+		{Rule: "missing-required-prop", Component: "Button", usage: usage, prop: "type"},
+		{Rule: "missing-required-prop", Component: "Button", usage: usage, prop: "tabIndex"},
+		{Rule: "missing-required-prop", Component: "Button", usage: usage, prop: "label"},
+	}
+
+	fixes, fixed := GenerateFixes(code, violations, v.index)
+
+	require.Len(t, fixes, 2)
+	assert.Equal(t, `<Button tabIndex={0} type="button">Go</Button>`, fixed)
+}
+
+func TestGenerateFixes_MissingChild(t *testing.T) {
+	v := testValidator()
+	code := "<Dialog>\n  <DialogContent>\n    <p>Body</p>\n  </DialogContent>\n</Dialog>"
+	content := &JSXUsage{
+		ComponentName: "DialogContent",
+		tagSpan:       spanOf(code, "DialogContent"),
+		closeTagSpan:  spanAt(code, "DialogContent", 2),
+		bodySpan:      span{start: strings.Index(code, "<p>") - 5, end: strings.Index(code, "</DialogContent>")},
+	}
+	violations := []Violation{{Rule: "missing-child", Component: "DialogContent", usage: content, replacement: "DialogTitle"}}
+
+	fixes, fixed := GenerateFixes(code, violations, v.index)
+
+	require.Len(t, fixes, 2)
+	assert.Equal(t, "import { DialogTitle } from \"@/components/ui/dialog\"\n"+
+		"<Dialog>\n  <DialogContent>\n    <DialogTitle></DialogTitle>\n    <p>Body</p>\n  </DialogContent>\n</Dialog>", fixed)
+
+	// Children on the same line stay on one line; a self-closing parent is left alone.
+	code = "import { DialogContent, DialogTitle } from \"@/components/ui/dialog\"\n<DialogContent><p>Body</p></DialogContent>"
+	content = &JSXUsage{ComponentName: "DialogContent", closeTagSpan: spanAt(code, "DialogContent", 3), bodySpan: spanOf(code, "<p>Body</p>")}
+	violations = []Violation{{Rule: "missing-child", Component: "DialogContent", usage: content, replacement: "DialogTitle"}}
+	fixes, fixed = GenerateFixes(code, violations, v.index)
+	require.Len(t, fixes, 1)
+	assert.Contains(t, fixed, "\n<DialogContent><DialogTitle></DialogTitle><p>Body</p></DialogContent>")
+
+	violations[0].usage = &JSXUsage{ComponentName: "DialogContent"}
+	fixes, _ = GenerateFixes("<DialogContent />", violations, v.index)
+	assert.Empty(t, fixes)
+}
+
+func TestGenerateFixes_CompositionWrap(t *testing.T) {
+	v := testValidator()
+	code := "import { DialogTrigger } from \"@/components/ui/dialog\";\n<div><DialogTrigger>Open</DialogTrigger></div>"
+	trigger := &JSXUsage{ComponentName: "DialogTrigger", span: spanOf(code, "<DialogTrigger>Open</DialogTrigger>")}
+	violations := []Violation{{Rule: "composition-violation", Component: "DialogTrigger", usage: trigger, replacement: "Dialog"}}
+
+	fixes, fixed := GenerateFixes(code, violations, v.index)
+
+	require.Len(t, fixes, 3)
+	assert.Equal(t, "import { DialogTrigger } from \"@/components/ui/dialog\";\nimport { Dialog } from \"@/components/ui/dialog\";\n"+
+		"<div><Dialog><DialogTrigger>Open</DialogTrigger></Dialog></div>", fixed)
+}
+
+func TestGenerateFixes_Deprecated(t *testing.T) {
+	v := testValidator()
+	code := `<OldButton kind="outline">Go</OldButton>`
+	usage := &JSXUsage{
+		ComponentName: "OldButton",
+		tagSpan:       spanOf(code, "OldButton"),
+		closeTagSpan:  spanAt(code, "OldButton", 2),
+		attrs:         map[string]attrSpan{"kind": {name: spanOf(code, "kind"), value: spanOf(code, `"outline"`)}},
+	}
+	violations := []Violation{
+		{Rule: "deprecated-component", Component: "OldButton", usage: usage, replacement: "Button"},
+		{Rule: "deprecated-prop", Component: "OldButton", usage: usage, prop: "kind", replacement: "variant"},
+		// No replacement in the catalog: nothing to fix.
+		{Rule: "deprecated-prop", Component: "OldButton", usage: usage, prop: "kind"},
+	}
+
+	fixes, fixed := GenerateFixes(code, violations, v.index)
+
+	require.Len(t, fixes, 4)
+	assert.Equal(t, "import { Button } from \"@/components/ui/button\"\n<Button variant=\"outline\">Go</Button>", fixed)
+}
+
 func TestValidatePage_Summary(t *testing.T) {
 	v := testValidator()
 	defer v.parser.Close()
@@ -268,3 +1252,40 @@ func TestValidatePage_Summary(t *testing.T) {
 	assert.NotEmpty(t, result.Summary)
 	assert.NotEqual(t, "no issues found", result.Summary)
 }
+
+// spanOf returns the span of the first occurrence of text in code.
+func spanOf(code, text string) span {
+	return spanAt(code, text, 1)
+}
+
+// spanAt returns the span of the nth occurrence of text in code.
+func spanAt(code, text string, n int) span {
+	offset := 0
+	for ; n > 1; n-- {
+		offset += strings.Index(code[offset:], text) + len(text)
+	}
+	start := offset + strings.Index(code[offset:], text)
+	return span{start: start, end: start + len(text)}
+}
+
+func TestValidationResult_Retain(t *testing.T) {
+	result := &ValidationResult{
+		Violations: []Violation{
+			{Rule: "missing-import", Severity: "error"},
+			{Rule: "unknown-prop", Severity: "info"},
+		},
+		Fixes: []AutoFix{{Rule: "missing-import", Violation: 0}, {Rule: "unknown-prop", Violation: 1}},
+	}
+
+	result.Retain(func(v Violation) bool { return v.Severity != "error" })
+
+	assert.True(t, result.Valid)
+	assert.Equal(t, []Violation{{Rule: "unknown-prop", Severity: "info"}}, result.Violations)
+	assert.Equal(t, "1 info(s)", result.Summary)
+	assert.Equal(t, -1, result.Fixes[0].Violation)
+	assert.Equal(t, 0, result.Fixes[1].Violation)
+
+	result.Retain(func(Violation) bool { return false })
+	assert.Empty(t, result.Violations)
+	assert.Equal(t, "no issues found", result.Summary)
+}